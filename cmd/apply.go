@@ -0,0 +1,99 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use: "apply [flags] -f <manifest>",
+	Example: `env-manager-v2 apply -f env-manager.yaml
+env-manager-v2 apply -f env-manager.yaml --quiet -k`,
+	Short: "Make the providers match a manifest",
+	Long: `Compare the environment variables and secrets described in a YAML manifest with the ones stored in the
+provider of each project environment, show the changes as the plan command does and, after confirmation,
+create, update and delete the keys so each environment matches the manifest. Use the k8s flag to apply the
+same changes to the ConfigMap and Secret of each environment. See the plan command for the manifest format.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isK8s, err := cmd.Flags().GetBool("k8s")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		manifestPath, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		manifestPlans, err := PlanManifest(manifestPath)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		if !PrintManifestPlans(manifestPlans) {
+			fmt.Println("No changes made")
+			return
+		}
+
+		if !isQuiet && !utils.GetUserPermission("Are you sure you want to apply the changes?") {
+			return
+		}
+
+		for _, manifestPlan := range manifestPlans {
+			if manifestPlan.EnvDiff.HasChanges() {
+				ApplyManifestPlan(manifestPlan, isK8s)
+			}
+		}
+	},
+}
+
+// ApplyManifestPlan creates, updates and deletes the keys of a target so it matches the manifest
+func ApplyManifestPlan(manifestPlan ManifestPlan, isK8s bool) {
+	target := manifestPlan.Target.Target
+	envFile := manifestPlan.EnvFile
+
+	createdEnvs, updatedEnvs, changedEnvs := utils.UpsertEnvironmentVariables(envFile, manifestPlan.Target.Envs)
+	deletedEnvs := manifestPlan.EnvDiff.OnlyInLeft
+	utils.DeleteEnvironmentVariables(envFile, deletedEnvs, target.Project, target.Environment)
+
+	err := utils.SaveTarget(manifestPlan.Provider, target, envFile, isK8s, changedEnvs, deletedEnvs)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	printSaved("Environment variables saved in project \"%s\" in \"%s\" environment: %d created, %d updated, %d deleted\n", target.Project, target.Environment, len(createdEnvs), len(updatedEnvs), len(deletedEnvs))
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringP("file", "f", "", "Specify the manifest file")
+	applyCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before applying the changes")
+	applyCmd.Flags().BoolP("k8s", "k", false, "Apply the changes to the Kubernetes cluster")
+
+	applyCmd.MarkFlagRequired("file")
+
+	applyCmd.RegisterFlagCompletionFunc("file", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"yaml", "yml"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+
+	applyCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	applyCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use: "backup [flags] -o <file>",
+	Example: `env-manager-v2 backup -o env-manager-2025-01-31.bak
+ENV_MANAGER_BACKUP_PASSPHRASE=... env-manager-v2 backup -o /backups/env-manager.bak --quiet`,
+	Short: "Back up the environment variables and secrets of all projects to an encrypted file",
+	Long: `Back up the environment variables and secrets of every environment of every project, from whichever
+provider each one uses, to a single file encrypted with a passphrase (AES-256-GCM with a scrypt derived key).
+The passphrase is read from the ENV_MANAGER_BACKUP_PASSPHRASE environment variable or asked for. Use the
+restore command to restore all or part of the backup, to any provider.
+
+Providers that keep the envs and secrets of an environment together, such as AWS Amplify, are backed up once,
+as envs. DigitalOcean secrets are backed up with the encrypted values DigitalOcean returns, which can only be restored
+to the same DigitalOcean app. If some environment can't be read, the others are still backed up and the command
+exits with an error.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		outputPath, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		if _, err := os.Stat(outputPath); err == nil && !isQuiet {
+			if !utils.GetUserPermission(fmt.Sprintf("File \"%s\" already exists. Are you sure you want to overwrite it?", outputPath)) {
+				return
+			}
+		}
+
+		passphrase, err := utils.GetBackupPassphrase(true)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		backup := &utils.Backup{CreatedAt: time.Now().UTC()}
+		failedTargets := 0
+
+		for _, project := range utils.ValidProjects {
+			projEnvironments, err := utils.GetProjectEnvironments(project)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				failedTargets++
+				continue
+			}
+
+			for _, projEnv := range projEnvironments {
+				provider, err := utils.GetProvider(project, projEnv)
+				if err != nil {
+					fmt.Printf("Error getting provider of project \"%s\" in \"%s\" environment: %v\n", project, projEnv, err)
+					failedTargets++
+					continue
+				}
+
+				for _, envType := range utils.ValidTypes {
+					target := utils.Target{Project: project, Environment: projEnv, EnvType: envType}
+
+					// Providers that keep all types together are backed up once, as the first type
+					if envType != utils.ValidTypes[0] && !utils.SeparatesTypes(provider, target) {
+						continue
+					}
+
+					envFile, err := provider.Load(target)
+					if err != nil {
+						fmt.Printf("Error loading %s: %v\n", provider.Describe(target), err)
+						failedTargets++
+						continue
+					}
+
+					backup.Entries = append(backup.Entries, utils.BackupEntry{
+						Project:     project,
+						Environment: projEnv,
+						EnvType:     envType,
+						Provider:    provider.Name(),
+						Envs:        envFile.Section("").KeysHash(),
+					})
+					fmt.Printf("Backed up %d %s of project \"%s\" in \"%s\" environment\n", len(envFile.Section("").Keys()), envType, project, projEnv)
+				}
+			}
+		}
+
+		encryptedBackup, err := utils.EncryptBackup(backup, passphrase)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		err = utils.WriteBackupFile(outputPath, encryptedBackup)
+		if err != nil {
+			log.Fatalf("Error writing file: %v", err)
+		}
+		fmt.Printf("Backup of %d environment variable sets saved in \"%s\"\n", len(backup.Entries), outputPath)
+
+		if failedTargets > 0 {
+			log.Fatalf("Error: the backup is incomplete, %d environment variable sets couldn't be read", failedTargets)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+
+	backupCmd.Flags().StringP("output", "o", "", "Specify the backup file")
+	backupCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before overwriting the backup file")
+
+	backupCmd.MarkFlagRequired("output")
+
+	backupCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	})
+
+	backupCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -6,16 +6,10 @@ package cmd
 import (
 	"fmt"
 	"log"
-	"os"
 	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/service/amplify"
-	"github.com/digitalocean/godo"
-	"github.com/oracle/oci-go-sdk/example/helpers"
-	"github.com/oracle/oci-go-sdk/v49/objectstorage"
 	"github.com/spf13/cobra"
 	"github.com/stanyzra/env-manager-v2/internal/utils"
-	"gopkg.in/ini.v1"
 )
 
 // deleteCmd represents the delete command
@@ -80,191 +74,54 @@ flag is ignored. The file should be in INI format WITH keys and values, even tho
 		projEnvironmentList := []string{projEnvironment}
 
 		if projEnvironment == "all" {
-			projEnvironmentList = utils.ValidEnvs
+			projEnvironmentList = utils.GetAllEnvironments(project, utils.ValidEnvs)
 		}
 
-		for _, projEnv := range projEnvironmentList {
-			provider, err := utils.GetConfigProperty(project, projEnv+".provider")
-
-			if err != nil {
-				fmt.Println("Error getting provider: ", err)
-				return
-			}
-			switch provider {
-			case "OCI":
-				fileName := fmt.Sprintf("%s_%s", projEnv, envType)
-
-				configProvider, _, err := utils.GetConfigProviderOCI()
-
-				if err != nil {
-					fmt.Println("Error getting config provider: ", err)
-					return
-				}
-
-				ociNamespace, err := utils.GetConfigProperty("OCI", "namespace")
-
-				if err != nil {
-					fmt.Println("Error getting namespace: ", err)
-					return
-				}
-
-				client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(configProvider)
-				helpers.FatalIfError(err)
-
-				if filePath != "" {
-					fmt.Printf("Deleting from file: %s\n", filePath)
-					DeleteFromFile(client, ociNamespace, project, projEnv, envType, filePath, fileName, isQuiet, isK8s)
-				} else {
-					DeleteFromArgs(client, ociNamespace, project, projEnv, envType, args, fileName, isQuiet, isK8s)
-				}
-			case "AWS":
-				projEnv, err = utils.GetConfigProperty(project, projEnv+".branch_name")
-
-				if err != nil {
-					fmt.Println("Error getting project environment: ", err)
-					return
-				}
-
-				configProvider, _, err := utils.GetConfigProviderAWS()
-				if err != nil {
-					fmt.Println("Error getting config provider: ", err)
-					return
-				}
-
-				client := amplify.NewFromConfig(configProvider)
-				utils.HandleAWS(client, project, projEnv, false, filePath, args, "", "", isQuiet, cmd.Name())
-
-			case "DGO":
-				client, err := utils.GetClientDGO()
-				if err != nil {
-					fmt.Println("Error getting client: ", err)
-					return
-				}
-
-				DeleteDGOEnv(client, project, projEnv, filePath, args, isQuiet)
-
-			default:
-				fmt.Println("Invalid provider")
-				return
-			}
-		}
-	},
-}
-
-func DeleteDGOEnv(client *godo.Client, project string, projEnvironment string, filePath string, envNames []string, isQuiet bool) {
-	dgoAppName, err := utils.GetConfigProperty(project, projEnvironment+".app_name")
-
-	if err != nil {
-		fmt.Println("Error getting app name: ", err)
-		return
-	}
-
-	dgoApp := utils.GetDGOApp(client, dgoAppName)
-	isSaved := false
-
-	deleteEnvs := func(component *godo.AppStaticSiteSpec) (bool, error) {
-		envsAsIni := utils.GetDGOEnvsAsIni(component.Envs)
-
-		if filePath == "" {
-			isSaved = utils.DeleteEnvironmentVariables(envsAsIni, envNames, project, projEnvironment)
-		} else {
-			userEnvFile, err := ini.Load(filePath)
+		envNames := args
+		if filePath != "" {
+			userEnvFile, err := utils.LoadUserEnvFile(filePath)
 			if err != nil {
 				fmt.Println("Error loading file: ", err)
-				if _, err := os.Stat(filePath); err == nil {
-					fmt.Println("Are you sure the file are in INI format (<key>=<value>)?")
-				}
-				return false, nil
+				return
 			}
 
-			isSaved = utils.DeleteEnvironmentVariables(envsAsIni, userEnvFile.Section("").KeyStrings(), project, projEnvironment)
+			envNames = userEnvFile.Section("").KeyStrings()
+			fmt.Printf("Deleting from file: %s\n", filePath)
 		}
 
-		if isSaved {
-			if isQuiet || utils.GetUserPermission("Are you sure you want to delete the environment variables?") {
-				component.Envs = utils.GetDGOEnvsFromIni(envsAsIni)
-			}
-		}
-
-		return isSaved, nil
-	}
-
-	err = utils.UpdateDGOApp(client, project, dgoApp, deleteEnvs)
-	if err != nil {
-		fmt.Println("Error updating app: ", err)
-		return
-	}
-
-	if isSaved {
-		fmt.Printf("Environment variables deleted in project \"%s\" in \"prod\" environment\n", project)
-	}
-
-}
-
-func ConfirmAndSave(client objectstorage.ObjectStorageClient, namespace, project, fileName, projEnvironment string, envFile *ini.File, isQuiet bool) {
-	if isQuiet || utils.GetUserPermission("Are you sure you want to delete the environment variables?") {
-		utils.SaveEnvFile(client, namespace, project, fileName, envFile, utils.BucketName)
-		fmt.Printf("Environment variables deleted in project \"%s\" in \"%s\" environment\n", project, projEnvironment)
-	}
-}
-
-func DeleteFromArgs(client objectstorage.ObjectStorageClient, namespace string, project string, projEnvironment string, envType string, envNames []string, fileName string, isQuiet bool, isK8s bool) {
-	envFile, err := utils.GetEnvsFileAsIni(project, fileName, client, namespace, utils.BucketName)
-	if err != nil {
-		fmt.Println("Error getting environment file: ", err)
-		return
-	}
+		for _, projEnv := range projEnvironmentList {
+			target := utils.Target{Project: project, Environment: projEnv, EnvType: envType}
 
-	if utils.DeleteEnvironmentVariables(envFile, envNames, project, projEnvironment) {
-		if isK8s {
-			k8sClient, err := utils.GetK8sClient()
+			provider, err := utils.GetProvider(project, projEnv)
 			if err != nil {
-				log.Fatalf("Error getting Kubernetes client: %v", err)
+				fmt.Println("Error getting provider: ", err)
+				return
 			}
-			manager, resourceName := utils.GetK8sResourceDataParams(k8sClient, project, projEnvironment, envType)
-
-			err = utils.DeleteK8sResourceKey(manager, resourceName, envNames)
 
-			if err != nil {
-				log.Fatalf("Failed to update resource data: %v", err)
-			}
+			DeleteEnvs(provider, target, envNames, isQuiet, isK8s)
 		}
-		ConfirmAndSave(client, namespace, project, fileName, projEnvironment, envFile, isQuiet)
-	}
+	},
 }
 
-func DeleteFromFile(client objectstorage.ObjectStorageClient, namespace string, project string, projEnvironment string, envType string, filePath string, fileName string, isQuiet bool, isK8s bool) {
-	userEnvFile, err := ini.Load(filePath)
+// DeleteEnvs deletes environment variables from a target after asking for confirmation
+func DeleteEnvs(provider utils.Provider, target utils.Target, envNames []string, isQuiet bool, isK8s bool) {
+	envFile, err := provider.Load(target)
 	if err != nil {
-		fmt.Println("Error loading file: ", err)
-		if _, err := os.Stat(filePath); err == nil {
-			fmt.Println("Are you sure the file are in INI format (<key>=<value>)?")
-		}
+		fmt.Printf("Error loading %s: %v\n", provider.Describe(target), err)
 		return
 	}
 
-	envFile, err := utils.GetEnvsFileAsIni(project, fileName, client, namespace, utils.BucketName)
-
-	if err != nil {
-		fmt.Println("Error loading file: ", err)
+	if !utils.DeleteEnvironmentVariables(envFile, envNames, target.Project, target.Environment) {
 		return
 	}
 
-	if utils.DeleteEnvironmentVariables(envFile, userEnvFile.Section("").KeyStrings(), project, projEnvironment) {
-		if isK8s {
-			k8sClient, err := utils.GetK8sClient()
-			if err != nil {
-				log.Fatalf("Error getting Kubernetes client: %v", err)
-			}
-			manager, resourceName := utils.GetK8sResourceDataParams(k8sClient, project, projEnvironment, envType)
-
-			err = utils.DeleteK8sResourceKey(manager, resourceName, userEnvFile.Section("").KeyStrings())
-
-			if err != nil {
-				log.Fatalf("Failed to update resource data: %v", err)
-			}
+	if isQuiet || utils.GetUserPermission("Are you sure you want to delete the environment variables?") {
+		err = utils.SaveTarget(provider, target, envFile, isK8s, nil, envNames)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
 		}
-		ConfirmAndSave(client, namespace, project, fileName, projEnvironment, envFile, isQuiet)
+		printSaved("Environment variables deleted in project \"%s\" in \"%s\" environment\n", target.Project, target.Environment)
 	}
 }
 
@@ -0,0 +1,175 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+	"gopkg.in/ini.v1"
+)
+
+// Exit codes of the diff command
+const (
+	diffChangesExitCode = 1
+	diffErrorExitCode   = 2
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use: "diff [flags] -p <project-name> [-p <project-name>] -e <project-environment> [-e <project-environment>]",
+	Example: `env-manager-v2 diff -p collection-back-end-v2.1 -e homolog -e prod
+env-manager-v2 diff -p collection-back-end-v2.1 -e homolog -e prod -t secrets
+env-manager-v2 diff -p gollection-elastic -p collection-back-end-v2.1 -e prod
+env-manager-v2 diff -p gollection-elastic -e dev -p collection-back-end-v2.1 -e prod`,
+	Short: "Compare the environment variables or secrets of two environments or projects",
+	Long: `Compare the environment variables or secrets of two project environments, which can use
+different providers. Use two environment flags to compare environments of a project, or two project
+flags to compare projects. It reports the keys found only in one side, the keys with different values
+and the identical keys. Values are compared by their SHA-256 hashes and secret values are never printed.
+The command exits with code 0 when the environments are identical, 1 when differences are found and
+2 on errors. DigitalOcean secrets are encrypted, so only their keys are compared: the keys in both sides are
+listed as not comparable and don't count as differences.`,
+	Annotations: map[string]string{errorExitCodeAnnotation: strconv.Itoa(diffErrorExitCode)},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		projects, err := cmd.Flags().GetStringArray("project")
+		if err != nil {
+			fatalWithExitCode(diffErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		projEnvironments, err := cmd.Flags().GetStringArray("environment")
+		if err != nil {
+			fatalWithExitCode(diffErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, false)
+		if err != nil {
+			fatalWithExitCode(diffErrorExitCode, "Error: %v", err)
+		}
+
+		if len(projects) > 2 || len(projEnvironments) > 2 {
+			fatalWithExitCode(diffErrorExitCode, "Error: use at most two project flags and two environment flags")
+		}
+
+		if len(projects) < 2 && len(projEnvironments) < 2 {
+			fatalWithExitCode(diffErrorExitCode, "Error: requires two project flags or two environment flags to compare")
+		}
+
+		left := utils.Target{Project: projects[0], Environment: projEnvironments[0], EnvType: envType}
+		right := utils.Target{Project: projects[len(projects)-1], Environment: projEnvironments[len(projEnvironments)-1], EnvType: envType}
+
+		for _, target := range []utils.Target{left, right} {
+			err = utils.ValidateProjectEnvironment(target.Project, target.Environment)
+			if err != nil {
+				fatalWithExitCode(diffErrorExitCode, "Error: %v", err)
+			}
+		}
+
+		leftProvider, leftEnvs, err := utils.LoadTarget(left)
+		if err != nil {
+			fatalWithExitCode(diffErrorExitCode, "Error: %v", err)
+		}
+
+		rightProvider, rightEnvs, err := utils.LoadTarget(right)
+		if err != nil {
+			fatalWithExitCode(diffErrorExitCode, "Error: %v", err)
+		}
+
+		envDiff := utils.DiffEnvs(leftEnvs, rightEnvs)
+
+		// DigitalOcean only returns the encrypted values of secrets, which never match the ones of another environment
+		if utils.HasEncryptedValues(leftProvider, envType) || utils.HasEncryptedValues(rightProvider, envType) {
+			fmt.Println("[WARNING] DigitalOcean secrets are encrypted, so only their keys are compared")
+			envDiff = utils.DiffEnvKeys(leftEnvs, rightEnvs)
+		}
+
+		PrintEnvDiff(envDiff, left, right, leftEnvs, rightEnvs)
+
+		if envDiff.HasChanges() {
+			os.Exit(diffChangesExitCode)
+		}
+	},
+}
+
+// PrintEnvDiff prints a report of the differences between two targets. Secret values are never printed.
+func PrintEnvDiff(envDiff utils.EnvDiff, left utils.Target, right utils.Target, leftEnvs *ini.File, rightEnvs *ini.File) {
+	leftName := fmt.Sprintf("%s/%s", left.Project, left.Environment)
+	rightName := fmt.Sprintf("%s/%s", right.Project, right.Environment)
+
+	fmt.Printf("Comparing %s of \"%s\" with \"%s\"\n", left.EnvType, leftName, rightName)
+
+	fmt.Printf("\nOnly in \"%s\" (%d):\n", leftName, len(envDiff.OnlyInLeft))
+	for _, envName := range envDiff.OnlyInLeft {
+		fmt.Printf("  %s\n", envName)
+	}
+
+	fmt.Printf("\nOnly in \"%s\" (%d):\n", rightName, len(envDiff.OnlyInRight))
+	for _, envName := range envDiff.OnlyInRight {
+		fmt.Printf("  %s\n", envName)
+	}
+
+	fmt.Printf("\nDifferent values (%d):\n", len(envDiff.Changed))
+	for _, envName := range envDiff.Changed {
+		if left.EnvType == "secrets" {
+			fmt.Printf("  %s\n", envName)
+		} else {
+			fmt.Printf("  %s: \"%s\" != \"%s\"\n", envName, leftEnvs.Section("").Key(envName).Value(), rightEnvs.Section("").Key(envName).Value())
+		}
+	}
+
+	fmt.Printf("\nIdentical (%d):\n", len(envDiff.Identical))
+	for _, envName := range envDiff.Identical {
+		fmt.Printf("  %s\n", envName)
+	}
+
+	if len(envDiff.NotComparable) > 0 {
+		fmt.Printf("\nNot comparable (encrypted) (%d):\n", len(envDiff.NotComparable))
+		for _, envName := range envDiff.NotComparable {
+			fmt.Printf("  %s\n", envName)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type")
+	diffCmd.Flags().StringArrayP("project", "p", []string{}, "Specify the project name. Use it twice to compare two projects")
+	diffCmd.Flags().StringArrayP("environment", "e", []string{}, "Specify the project environment. Use it twice to compare two environments")
+
+	diffCmd.MarkFlagRequired("project")
+	diffCmd.MarkFlagRequired("environment")
+
+	diffCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	diffCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	diffCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects, err := cmd.Flags().GetStringArray("project")
+		if err != nil || len(projects) == 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(projects[len(projects)-1], "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:     "doctor [flags]",
+	Aliases: []string{"validate"},
+	Example: `env-manager-v2 doctor
+env-manager-v2 validate --config-only`,
+	Short: "Check the config file, the provider credentials and the connectivity",
+	Long: `Check the structure of the config file: every project listed in [PROJECTS] has a section, every
+environment has a valid provider and the properties it needs (app_name and app_component_name for DigitalOcean,
+branch_name for AWS Amplify), the Kubernetes settings are complete, and the config sections of the providers
+in use have their credentials. Each problem is reported with its line in the config file.
+
+Then, unless the config-only flag is used, the credentials and connectivity are checked by reading the
+environment variables of every project environment from its provider, and its ConfigMap and Secret when
+Kubernetes is configured. No variable is printed or changed. The command exits with code 1 when any problem
+is found.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isConfigOnly, err := cmd.Flags().GetBool("config-only")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		configFileName := utils.GetConfigFileName()
+		content, err := os.ReadFile(configFileName)
+		if err != nil {
+			log.Fatalf("Error reading config file: %v", err)
+		}
+
+		configProblems, err := utils.ValidateConfig(content)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		fmt.Printf("Checking config file \"%s\"\n", configFileName)
+		for _, problem := range configProblems {
+			if problem.Line > 0 {
+				fmt.Printf("  %s:%d: %s\n", configFileName, problem.Line, problem.Message)
+			} else {
+				fmt.Printf("  %s: %s\n", configFileName, problem.Message)
+			}
+		}
+		if len(configProblems) == 0 {
+			fmt.Println("  [OK] no problems found")
+		}
+
+		connectivityProblems := 0
+		if !isConfigOnly {
+			fmt.Println("Checking credentials and connectivity")
+			connectivityProblems = CheckConnectivity()
+		}
+
+		if problems := len(configProblems) + connectivityProblems; problems > 0 {
+			log.Fatalf("Error: found %d problems", problems)
+		}
+	},
+}
+
+// CheckConnectivity reads the environment variables of every project environment, and its Kubernetes ConfigMap
+// and Secret when configured, printing the result of each one. Returns the number of failures.
+func CheckConnectivity() int {
+	problems := 0
+	providers := map[string]utils.Provider{}
+	providerErrors := map[string]error{}
+
+	for _, project := range utils.ValidProjects {
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			fmt.Printf("  [ERROR] project \"%s\": %v\n", project, err)
+			problems++
+			continue
+		}
+
+		for _, projEnv := range projEnvironments {
+			providerName := utils.GetConfigPropertyOrDefault(project, projEnv+".provider", "")
+			if providerName == "" {
+				continue
+			}
+
+			// Each provider is created once, so a credentials error is reported once
+			provider, ok := providers[providerName]
+			if !ok && providerErrors[providerName] == nil {
+				provider, err = utils.GetProviderByName(providerName)
+				if err != nil {
+					fmt.Printf("  [ERROR] %s provider: %v\n", providerName, err)
+					providerErrors[providerName] = err
+					problems++
+				}
+				providers[providerName] = provider
+			}
+			if provider == nil {
+				continue
+			}
+
+			target := utils.Target{Project: project, Environment: projEnv, EnvType: "envs"}
+			if _, err := provider.Load(target); err != nil {
+				fmt.Printf("  [ERROR] %s: %v\n", provider.Describe(target), err)
+				problems++
+			} else {
+				fmt.Printf("  [OK] %s\n", provider.Describe(target))
+			}
+
+			if !utils.IsK8sTargetConfigured(project, projEnv) {
+				continue
+			}
+
+			for _, envType := range utils.ValidTypes {
+				target := utils.Target{Project: project, Environment: projEnv, EnvType: envType}
+				if _, err := utils.LoadK8sTarget(target); err != nil {
+					fmt.Printf("  [ERROR] %s: %v\n", utils.DescribeK8sTarget(target), err)
+					problems++
+				} else {
+					fmt.Printf("  [OK] %s\n", utils.DescribeK8sTarget(target))
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().Bool("config-only", false, "Only check the config file, without connecting to the providers")
+
+	doctorCmd.RegisterFlagCompletionFunc("config-only", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,205 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+	"gopkg.in/ini.v1"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use: "edit [flags] -p <project-name> -e <project-environment>",
+	Example: `env-manager-v2 edit -p collection-back-end-v2.1 -e dev
+env-manager-v2 edit -p collection-back-end-v2.1 -e prod -t secrets -k
+EDITOR="code --wait" env-manager-v2 edit -p gollection-elastic -e homolog`,
+	Short: "Edit the environment variables or secrets of a project in your editor",
+	Long: `Open the environment variables or secrets of a project environment in your editor ($VISUAL, $EDITOR or vi)
+as a temporary dotenv file, readable only by you and removed after editing. When the editor is closed, the
+added, changed and removed variables are shown and saved after confirmation. If the file can't be parsed,
+you can edit it again. Use the k8s flag to apply the same changes to the Kubernetes ConfigMap or Secret.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isK8s, err := cmd.Flags().GetBool("k8s")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		target := utils.Target{Project: project, Environment: projEnvironment, EnvType: envType}
+
+		provider, envFile, err := utils.LoadTarget(target)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		editedEnvFile, err := EditEnvs(provider, target, envFile)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		if editedEnvFile == nil {
+			return
+		}
+
+		err = ReplaceEnvs(provider, target, envFile, editedEnvFile, isK8s, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+	},
+}
+
+// ReplaceEnvs shows the differences between the current and the new environment variables of a target and, unless
+// isQuiet is set, asks for confirmation before replacing them. With isK8s, the same changes are applied to the
+// Kubernetes ConfigMap or Secret.
+func ReplaceEnvs(provider utils.Provider, target utils.Target, envFile *ini.File, newEnvFile *ini.File, isK8s bool, isQuiet bool) error {
+	envDiff := utils.DiffEnvs(envFile, newEnvFile)
+	if !envDiff.HasChanges() {
+		fmt.Println("No changes made")
+		return nil
+	}
+
+	PrintEnvChanges(fmt.Sprintf("project \"%s\" in \"%s\" environment (%s)", target.Project, target.Environment, provider.Describe(target)), envDiff.OnlyInRight, envDiff.Changed, envDiff.OnlyInLeft)
+
+	if !isQuiet && !utils.GetUserPermission("Are you sure you want to save the environment variables?") {
+		return nil
+	}
+
+	changedEnvs := ini.Empty()
+	for _, envName := range append(envDiff.OnlyInRight, envDiff.Changed...) {
+		changedEnvs.Section("").Key(envName).SetValue(newEnvFile.Section("").Key(envName).Value())
+	}
+
+	err := utils.SaveTarget(provider, target, newEnvFile, isK8s, changedEnvs, envDiff.OnlyInLeft)
+	if err != nil {
+		return err
+	}
+	printSaved("Environment variables saved in project \"%s\" in \"%s\" environment\n", target.Project, target.Environment)
+	return nil
+}
+
+// EditEnvs opens the environment variables of a target in the user's editor and returns the edited ones, or nil if
+// the user gives up after an invalid edit
+func EditEnvs(provider utils.Provider, target utils.Target, envFile *ini.File) (*ini.File, error) {
+	content, err := utils.FormatEnvs(envFile, target, "dotenv")
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("# %s of project \"%s\" in \"%s\" environment (%s)\n", target.EnvType, target.Project, target.Environment, provider.Describe(target))
+	header += "# Add, change or remove variables, then save and close the editor. Lines starting with # are ignored.\n"
+	if target.EnvType == "secrets" && provider.Name() == "DGO" {
+		header += "# DigitalOcean secrets are shown encrypted. Keep the EV[...] values of the secrets you don't change.\n"
+	}
+
+	// os.CreateTemp creates the file readable only by the user
+	tempFile, err := os.CreateTemp("", "env-manager-*.env")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.WriteString(header + string(content))
+	tempFile.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error writing temporary file: %w", err)
+	}
+
+	return utils.EditDotenv(tempFile.Name(), envFile, openEditor, func(err error) bool {
+		fmt.Printf("Error parsing the edited file: %v\n", err)
+		return utils.GetUserPermission("Do you want to edit the file again?")
+	})
+}
+
+// openEditor opens a file in the editor set in $VISUAL or $EDITOR, which can have arguments, or in vi
+func openEditor(filePath string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if strings.TrimSpace(editor) == "" {
+		editor = "vi"
+	}
+
+	editorArgs := strings.Fields(editor)
+	editorCmd := exec.Command(editorArgs[0], append(editorArgs[1:], filePath)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	err := editorCmd.Run()
+	if err != nil {
+		return fmt.Errorf("error running editor \"%s\": %w", editor, err)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+
+	editCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type")
+	editCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	editCmd.Flags().StringP("environment", "e", "", "Specify the project environment")
+	editCmd.Flags().BoolP("k8s", "k", false, "Apply the changes to the Kubernetes cluster")
+
+	editCmd.MarkFlagRequired("project")
+	editCmd.MarkFlagRequired("environment")
+
+	editCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	editCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	editCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	editCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
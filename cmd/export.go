@@ -0,0 +1,164 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use: "export [flags] -p <project-name> -e <project-environment>",
+	Example: `env-manager-v2 export -p collection-back-end-v2.1 -e dev > .env
+env-manager-v2 export -p collection-back-end-v2.1 -e dev -t all --format shell
+env-manager-v2 export -p gollection-elastic -e prod --format json -o envs.json
+env-manager-v2 export -p collection-back-end-v2.1 -e prod -t secrets --format k8s | kubectl apply -f -`,
+	Short: "Export the environment variables or secrets of a project in several formats",
+	Long: `Export the environment variables or secrets of a project environment, from any provider, to stdout
+or to a file. The formats are:
+
+  dotenv  KEY=value lines, with values quoted and escaped when needed (default)
+  shell   export KEY='value' lines, to be sourced by a POSIX shell
+  json    a JSON object
+  yaml    a YAML map
+  docker  a Docker --env-file, which doesn't support multiline values
+  k8s     a Kubernetes ConfigMap (envs) or Secret (secrets) manifest, named after the configured resources
+
+Secret values are exported in plain text, so be careful where the output is written.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envTypeFlag, err := cmd.Flags().GetString("type")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envTypes, err := utils.ParseEnvTypes(envTypeFlag)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		format, err := utils.GetFlagString(cmd, "format", utils.ValidExportFormats, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		outputPath, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		// A Kubernetes manifest has a resource for each type, the other formats have a single set of variables
+		var output []byte
+		if format == "k8s" {
+			var manifests [][]byte
+			for _, envType := range envTypes {
+				envFile, err := utils.LoadEnvTypes(project, projEnvironment, []string{envType})
+				if err != nil {
+					log.Fatalf("Error: %v", err)
+				}
+
+				manifest, err := utils.FormatEnvs(envFile, utils.Target{Project: project, Environment: projEnvironment, EnvType: envType}, format)
+				if err != nil {
+					log.Fatalf("Error: %v", err)
+				}
+				manifests = append(manifests, manifest)
+			}
+			output = bytes.Join(manifests, []byte("---\n"))
+		} else {
+			envFile, err := utils.LoadEnvTypes(project, projEnvironment, envTypes)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+
+			output, err = utils.FormatEnvs(envFile, utils.Target{Project: project, Environment: projEnvironment}, format)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+		}
+
+		if outputPath == "" {
+			os.Stdout.Write(output)
+			return
+		}
+
+		err = os.WriteFile(outputPath, output, 0600)
+		if err != nil {
+			log.Fatalf("Error writing file: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Environment variables of project \"%s\" in \"%s\" environment exported to \"%s\"\n", project, projEnvironment, outputPath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable types, separated by commas (envs, secrets or all)")
+	exportCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	exportCmd.Flags().StringP("environment", "e", "", "Specify the project environment")
+	exportCmd.Flags().String("format", "dotenv", fmt.Sprintf("Specify the output format (options: %s)", strings.Join(utils.ValidExportFormats, ", ")))
+	exportCmd.Flags().StringP("output", "o", "", "Write to a file instead of stdout")
+
+	exportCmd.MarkFlagRequired("project")
+	exportCmd.MarkFlagRequired("environment")
+
+	exportCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	exportCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		types = append(types, "all")
+		return types, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	exportCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	exportCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		formats := []cobra.Completion{}
+		formats = append(formats, utils.ValidExportFormats...)
+		return formats, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	exportCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	})
+}
@@ -5,17 +5,10 @@ Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"io"
 	"log"
 	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/service/amplify"
-	"github.com/digitalocean/godo"
-	"github.com/oracle/oci-go-sdk/example/helpers"
-	"github.com/oracle/oci-go-sdk/v49/common"
-	"github.com/oracle/oci-go-sdk/v49/objectstorage"
 	"github.com/spf13/cobra"
 	"github.com/stanyzra/env-manager-v2/internal/utils"
 )
@@ -65,176 +58,63 @@ flag to get all of them. The project and environment flag is required.`,
 			log.Fatalf("Error reading option flag: %v", err)
 		}
 
-		provider, err := utils.GetConfigProperty(project, projEnvironment+".provider")
-
+		isShowLevels, err := cmd.Flags().GetBool("show-levels")
 		if err != nil {
-			fmt.Println("Error getting provider: ", err)
-			return
+			log.Fatalf("Error reading option flag: %v", err)
 		}
 
-		switch provider {
-		case "OCI":
-			configProvider, _, err := utils.GetConfigProviderOCI()
-			if err != nil {
-				fmt.Println("Error getting config provider: ", err)
-				return
-			}
-
-			client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(configProvider)
-			helpers.FatalIfError(err)
-
-			ociNamespace, err := utils.GetConfigProperty("OCI", "namespace")
-
-			if err != nil {
-				fmt.Println("Error getting namespace: ", err)
-				return
-			}
-
-			HandleOCI(client, ociNamespace, project, projEnvironment, envType, isGetAll, args)
+		target := utils.Target{Project: project, Environment: projEnvironment, EnvType: envType}
 
-		case "AWS":
-			// projEnvironment, err = utils.GetConfigProperty(project, projEnvironment, "branch_name")
-			projEnvironment, err = utils.GetConfigProperty(project, projEnvironment+".branch_name")
-
-			if err != nil {
-				fmt.Println("Error getting project environment: ", err)
-				return
-			}
-
-			configProvider, _, err := utils.GetConfigProviderAWS()
-			if err != nil {
-				fmt.Println("Error getting config provider: ", err)
-				return
-			}
-
-			client := amplify.NewFromConfig(configProvider)
-
-			utils.HandleAWS(client, project, projEnvironment, isGetAll, "", args, "", "", false, cmd.Name())
-
-		case "DGO":
-			client, err := utils.GetClientDGO()
-			if err != nil {
-				fmt.Println("Error getting client: ", err)
-				return
-			}
-
-			showEnvs(client, project, projEnvironment, isGetAll, args)
-
-		default:
-			fmt.Println("Invalid provider")
+		provider, err := utils.GetProvider(project, projEnvironment)
+		if err != nil {
+			fmt.Println("Error getting provider: ", err)
 			return
 		}
+
+		PrintEnvs(provider, target, isGetAll, isShowLevels, args)
 	},
 }
 
-func showEnvs(client *godo.Client, project string, projEnvironment string, isGetAll bool, envNames []string) {
-	dgoAppName, err := utils.GetConfigProperty(project, projEnvironment+".app_name")
+// PrintEnvs prints the environment variables of a target as KEY=VALUE lines. Secret values are censored. For
+// providers with inherited levels, it prints the effective variables and, if isShowLevels is set, the level
+// each one comes from.
+func PrintEnvs(provider utils.Provider, target utils.Target, isGetAll bool, isShowLevels bool, envNames []string) {
+	envFile, envLevels, err := utils.LoadEffectiveEnvs(provider, target)
 	if err != nil {
-		log.Fatalf("Error getting app name: %v", err)
-	}
-
-	specificApp := utils.GetDGOApp(client, dgoAppName)
-
-	printEnvs := func(component *godo.AppStaticSiteSpec) error {
-		if isGetAll {
-			for _, envVar := range component.Envs {
-				fmt.Printf("%s=%s\n", envVar.Key, envVar.Value)
-			}
-		} else {
-			envsAsIni := utils.GetDGOEnvsAsIni(component.Envs)
-			for _, envName := range envNames {
-				if envsAsIni.Section("").HasKey(envName) {
-					value := envsAsIni.Section("").Key(envName).String()
-					fmt.Printf("%s=%s\n", envName, value)
-				} else {
-					fmt.Printf("Environment variable \"%s\" not found in project \"%s\" in \"%s\" environment\n", envName, project, projEnvironment)
-				}
-			}
-		}
-		return nil
+		fmt.Printf("Error loading %s: %v\n", provider.Describe(target), err)
+		return
 	}
 
-	appComponentName, err := utils.GetConfigProperty(project, projEnvironment+".app_component_name")
-
-	if err != nil {
-		log.Fatalf("Error getting app component name: %v", err)
+	if isGetAll {
+		envNames = envFile.Section("").KeyStrings()
 	}
 
-	err = godo.ForEachAppSpecComponent(specificApp.Spec, func(component *godo.AppStaticSiteSpec) error {
-		if component.Name == appComponentName {
-			return printEnvs(component)
+	for _, envName := range envNames {
+		if !envFile.Section("").HasKey(envName) {
+			fmt.Printf("Environment variable \"%s\" not found in project \"%s\" in \"%s\" environment\n", envName, target.Project, target.Environment)
+			continue
 		}
-		return nil
-	})
-	if err != nil {
-		log.Fatalf("Error iterating over app components: %v", err)
-	}
-}
-
-func GetInputedEnv(client objectstorage.ObjectStorageClient, namespace string, project string, projEnvironment string, envType string, envNames []string) {
-	fileName := fmt.Sprintf("%s_%s", projEnvironment, envType)
 
-	envFile, err := utils.GetEnvsFileAsIni(project, fileName, client, namespace, utils.BucketName)
-
-	if err != nil {
-		fmt.Println("Error loading file: ", err)
-		return
-	}
-
-	for _, envName := range envNames {
 		value := envFile.Section("").Key(envName).String()
-		if value == "" {
-			fmt.Printf("Environment variable \"%s\" not found in project \"%s\" in \"%s\" environment\n", envName, project, projEnvironment)
-		} else if envType == "secrets" {
-			fmt.Printf("%s=***\n", envName)
+		if target.EnvType == "secrets" {
+			value = "***"
+		}
+
+		if levels, ok := envLevels[envName]; ok && isShowLevels {
+			fmt.Printf("%s=%s # %s\n", envName, value, formatEnvLevels(levels))
 		} else {
 			fmt.Printf("%s=%s\n", envName, value)
 		}
 	}
-
-}
-
-func HandleOCI(client objectstorage.ObjectStorageClient, namespace, project, projEnvironment, envType string, isGetAll bool, args []string) {
-	if isGetAll {
-		ReadFullObject(client, namespace, project, projEnvironment, envType)
-	} else {
-		envNames := args
-		GetInputedEnv(client, namespace, project, projEnvironment, envType, envNames)
-	}
 }
 
-func ReadFullObject(client objectstorage.ObjectStorageClient, namespace string, project string, projEnvironment string, envType string) {
-	fileName := fmt.Sprintf("%s_%s", projEnvironment, envType)
-
-	getRequest := objectstorage.GetObjectRequest{
-		NamespaceName: &namespace,
-		BucketName:    common.String(utils.BucketName),
-		ObjectName:    common.String(fmt.Sprintf("%s/env-files/.%s", project, fileName)),
-	}
-
-	getResponse, err := client.GetObject(context.Background(), getRequest)
-	helpers.FatalIfError(err)
-
-	content, err := io.ReadAll(getResponse.Content)
-	if err != nil {
-		fmt.Println("Error reading object content: ", err)
-		return
-	}
-
-	if envType == "envs" {
-		fmt.Println(string(content))
-	} else {
-		lines := strings.Split(string(content), "\n")
-		for i, line := range lines {
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				lines[i] = fmt.Sprintf("%s=***\n", parts[0])
-			}
-		}
-
-		censoredContent := strings.Join(lines, "")
-		fmt.Println(censoredContent)
+// formatEnvLevels describes the level a variable comes from and the levels it overrides
+func formatEnvLevels(levels []string) string {
+	level := levels[len(levels)-1]
+	if len(levels) > 1 {
+		return fmt.Sprintf("%s, overrides %s", level, strings.Join(levels[:len(levels)-1], ", "))
 	}
+	return level
 }
 
 func init() {
@@ -244,6 +124,7 @@ func init() {
 	getCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type")
 	getCmd.Flags().StringP("project", "p", "", "Specify the project name")
 	getCmd.Flags().StringP("environment", "e", "", "Specify the project environment")
+	getCmd.Flags().Bool("show-levels", false, "Show the level each environment variable comes from (app, component or branch)")
 
 	getCmd.MarkFlagRequired("project")
 	getCmd.MarkFlagRequired("environment")
@@ -278,4 +159,8 @@ func init() {
 	getCmd.RegisterFlagCompletionFunc("get-all", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	})
+
+	getCmd.RegisterFlagCompletionFunc("show-levels", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
 }
@@ -0,0 +1,187 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+	"gopkg.in/ini.v1"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use: "history [flags] -p <project-name> -e <project-environment>",
+	Example: `env-manager-v2 history -p collection-back-end-v2.1 -e prod
+env-manager-v2 history -p collection-back-end-v2.1 -e prod -t secrets --limit 30`,
+	Short: "List the previous versions of the environment variables or secrets of a project",
+	Long: `List the previous versions of the environment variables or secrets of a project environment, from the
+newest to the oldest, with the keys created, updated and deleted by each version. Secret values are never
+printed. Only providers that keep previous versions are supported: OCI, with object versioning enabled in the
+bucket. Use the rollback command to restore a version.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+		if limit < 1 {
+			log.Fatalf("Error: limit must be at least 1")
+		}
+
+		target := utils.Target{Project: project, Environment: projEnvironment, EnvType: envType}
+
+		provider, err := GetVersionedProvider(target)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		versions, err := provider.ListVersions(target)
+		if err != nil {
+			log.Fatalf("Error listing versions of %s: %v", provider.Describe(target), err)
+		}
+		if len(versions) == 0 {
+			log.Fatalf("Error: %s has no versions", provider.Describe(target))
+		}
+
+		fmt.Printf("History of %s:\n", provider.Describe(target))
+
+		// Each version is compared with the previous one, so one more version than shown is loaded
+		versionEnvFiles := make(map[string]*ini.File)
+		loadVersion := func(version utils.EnvVersion) *ini.File {
+			if version.IsDeleted {
+				return ini.Empty()
+			}
+			if _, ok := versionEnvFiles[version.ID]; !ok {
+				envFile, err := provider.LoadVersion(target, version.ID)
+				if err != nil {
+					log.Fatalf("Error loading version \"%s\" of %s: %v", version.ID, provider.Describe(target), err)
+				}
+				versionEnvFiles[version.ID] = envFile
+			}
+			return versionEnvFiles[version.ID]
+		}
+
+		for i, version := range versions {
+			if i == limit {
+				fmt.Printf("%d older versions not shown, use --limit to show them\n", len(versions)-limit)
+				break
+			}
+
+			status := ""
+			if i == 0 {
+				status = " (current)"
+			}
+			if version.IsDeleted {
+				status += " (deleted)"
+			}
+			fmt.Printf("%s  %s%s\n", version.ID, version.Time.UTC().Format("2006-01-02 15:04:05 MST"), status)
+
+			if version.IsDeleted {
+				continue
+			}
+
+			envFile := loadVersion(version)
+			if i == len(versions)-1 {
+				fmt.Printf("  first version, with %d keys\n", len(envFile.Section("").Keys()))
+				continue
+			}
+
+			envDiff := utils.DiffEnvs(loadVersion(versions[i+1]), envFile)
+			if !envDiff.HasChanges() {
+				fmt.Println("  no changes")
+			}
+			for _, envName := range envDiff.OnlyInRight {
+				fmt.Printf("  + %s (created)\n", envName)
+			}
+			for _, envName := range envDiff.Changed {
+				fmt.Printf("  ~ %s (updated)\n", envName)
+			}
+			for _, envName := range envDiff.OnlyInLeft {
+				fmt.Printf("  - %s (deleted)\n", envName)
+			}
+		}
+	},
+}
+
+// GetVersionedProvider returns the provider of a target if it keeps previous versions
+func GetVersionedProvider(target utils.Target) (utils.VersionedProvider, error) {
+	provider, err := utils.GetProvider(target.Project, target.Environment)
+	if err != nil {
+		return nil, fmt.Errorf("error getting provider: %w", err)
+	}
+
+	versionedProvider, ok := provider.(utils.VersionedProvider)
+	if !ok {
+		return nil, fmt.Errorf("the %s provider of project \"%s\" in \"%s\" environment doesn't keep previous versions", provider.Name(), target.Project, target.Environment)
+	}
+
+	return versionedProvider, nil
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type")
+	historyCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	historyCmd.Flags().StringP("environment", "e", "", "Specify the project environment")
+	historyCmd.Flags().Int("limit", 10, "Specify the maximum number of versions to show")
+
+	historyCmd.MarkFlagRequired("project")
+	historyCmd.MarkFlagRequired("environment")
+
+	historyCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	historyCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	historyCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	historyCmd.RegisterFlagCompletionFunc("limit", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,219 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+	"gopkg.in/ini.v1"
+)
+
+var validImportModes = []string{"create", "update", "upsert"}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use: "import [flags] -p <project-name> -e <project-environment> -f <file>",
+	Example: `env-manager-v2 import -p collection-back-end-v2.1 -e dev -f .env
+env-manager-v2 import -p collection-back-end-v2.1 -e all -t secrets -f secrets.json --mode create
+env-manager-v2 import -p gollection-elastic -e prod -f configmap.yaml -k
+kubectl get secret my-secret -o yaml | env-manager-v2 import -p gollection-elastic -e prod -f - --format k8s`,
+	Short: "Import environment variables or secrets from dotenv, JSON, YAML or Kubernetes manifest files",
+	Long: `Import the environment variables or secrets of a file into a project environment. The formats are:
+
+  dotenv  KEY=value lines, with optional "export" prefixes, comments, and single or double quoted values that
+          can span several lines
+  json    a JSON object with string, number or boolean values
+  yaml    a YAML map with string, number or boolean values
+  k8s     a Kubernetes ConfigMap or Secret manifest, with the base64 "data" and the "stringData" of Secrets decoded
+
+The format is detected from the file extension (.json, .yaml or .yml, and .env or any other for dotenv) unless
+the --format flag is used. A YAML file with "apiVersion" and "kind" is read as a Kubernetes manifest, and unless
+the type flag is used, a ConfigMap is imported as envs and a Secret as secrets. Use "-" as the file to read stdin.
+
+The --mode flag sets how the variables are merged with the existing ones: "create" only adds new variables, as
+the create command, "update" only changes existing ones, as the update command, and "upsert" (default) does both
+and asks for confirmation before saving.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isK8s, err := cmd.Flags().GetBool("k8s")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, true)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		mode, err := utils.GetFlagString(cmd, "mode", validImportModes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		filePath, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		var content []byte
+		if filePath == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = os.ReadFile(filePath)
+		}
+		if err != nil {
+			log.Fatalf("Error reading file: %v", err)
+		}
+
+		format := utils.DetectImportFormat(filePath, content)
+		if cmd.Flags().Changed("format") {
+			format, err = utils.GetFlagString(cmd, "format", utils.ValidImportFormats, false)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+		}
+
+		userEnvFile, err := utils.ParseEnvs(content, format)
+		if err != nil {
+			log.Fatalf("Error loading file: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		// A Kubernetes manifest tells the type of its variables
+		if format == "k8s" && !cmd.Flags().Changed("type") {
+			_, envType, err = utils.ParseK8sManifest(content)
+			if err != nil {
+				log.Fatalf("Error loading file: %v", err)
+			}
+		}
+
+		if len(userEnvFile.Section("").Keys()) == 0 {
+			log.Fatalf("Error: no environment variables found in \"%s\"", filePath)
+		}
+
+		projEnvironmentList := []string{projEnvironment}
+		if projEnvironment == "all" {
+			projEnvironmentList = utils.GetAllEnvironments(project, projEnvironments)
+		}
+
+		for _, projEnv := range projEnvironmentList {
+			target := utils.Target{Project: project, Environment: projEnv, EnvType: envType}
+
+			provider, err := utils.GetProvider(project, projEnv)
+			if err != nil {
+				fmt.Println("Error getting provider: ", err)
+				return
+			}
+
+			// The create and update helpers remove the skipped variables from the file they get
+			targetEnvFile := ini.Empty()
+			for _, key := range userEnvFile.Section("").Keys() {
+				targetEnvFile.Section("").Key(key.Name()).SetValue(key.Value())
+			}
+
+			switch mode {
+			case "create":
+				CreateEnvs(provider, target, targetEnvFile, isK8s)
+			case "update":
+				UpdateEnvs(provider, target, targetEnvFile, isK8s)
+			case "upsert":
+				UpsertEnvs(provider, target, targetEnvFile, isK8s, isQuiet)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type. For Kubernetes manifests, it defaults to the type of the manifest")
+	importCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	importCmd.Flags().StringP("environment", "e", "", "Specify the project environment (or all)")
+	importCmd.Flags().StringP("file", "f", "", "Specify the file to import, or - to read stdin")
+	importCmd.Flags().String("format", "", fmt.Sprintf("Specify the file format (options: %s). Detected from the file extension by default", strings.Join(utils.ValidImportFormats, ", ")))
+	importCmd.Flags().String("mode", "upsert", fmt.Sprintf("Specify how to merge the variables with the existing ones (options: %s)", strings.Join(validImportModes, ", ")))
+	importCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before saving the environment variables or secrets in upsert mode")
+	importCmd.Flags().BoolP("k8s", "k", false, "Create or update the environment variables or secrets in the Kubernetes cluster")
+
+	importCmd.MarkFlagRequired("project")
+	importCmd.MarkFlagRequired("environment")
+	importCmd.MarkFlagRequired("file")
+
+	importCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	importCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	importCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		validEnvs = append(validEnvs, "all")
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	importCmd.RegisterFlagCompletionFunc("file", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	})
+
+	importCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		formats := []cobra.Completion{}
+		formats = append(formats, utils.ValidImportFormats...)
+		return formats, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	importCmd.RegisterFlagCompletionFunc("mode", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		modes := []cobra.Completion{}
+		modes = append(modes, validImportModes...)
+		return modes, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	importCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	importCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use: "list [flags] [-p <project-name>]",
+	Example: `env-manager-v2 list
+env-manager-v2 list -p collection-back-end-v2.1 --count
+env-manager-v2 list projects
+env-manager-v2 list envs -p collection-back-end-v2.1`,
+	Short: "List the configured projects, environments and where their variables are stored",
+	Long: `Show a table with every configured project environment and where its variables are stored: the provider,
+the AWS Amplify branch, the DigitalOcean app and component, and the Kubernetes namespace, ConfigMap and Secret.
+Use the count flag to also read the number of envs and secrets stored, which connects to every provider.
+The projects and envs subcommands list only the projects or the environments of a project.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isCount, err := cmd.Flags().GetBool("count")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		projects := utils.ValidProjects
+		if cmd.Flags().Changed("project") {
+			project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			projects = []string{project}
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		header := "PROJECT\tENVIRONMENT\tPROVIDER\tBRANCH\tDO APP\tDO COMPONENT\tNAMESPACE\tCONFIGMAP\tSECRET"
+		if isCount {
+			header += "\tENVS\tSECRETS"
+		}
+		fmt.Fprintln(writer, header)
+
+		for _, project := range projects {
+			projEnvironments, err := utils.GetProjectEnvironments(project)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				continue
+			}
+
+			for _, projEnv := range projEnvironments {
+				envConfig := utils.GetProjectEnvironmentConfig(project, projEnv)
+
+				branchName, appComponentName := envConfig.BranchName, envConfig.AppComponentName
+				if envConfig.Scope == "app" {
+					branchName, appComponentName = "(app-level)", "(app-level)"
+				}
+
+				row := []string{project, projEnv, envConfig.Provider}
+				switch envConfig.Provider {
+				case "AWS":
+					row = append(row, branchName, "", "")
+				case "DGO":
+					row = append(row, "", envConfig.AppName, appComponentName)
+				default:
+					row = append(row, "", "", "")
+				}
+				row = append(row, envConfig.Namespace, envConfig.ConfigMapName, envConfig.SecretName)
+
+				if isCount {
+					for _, envType := range utils.ValidTypes {
+						row = append(row, getEnvCount(utils.Target{Project: project, Environment: projEnv, EnvType: envType}))
+					}
+				}
+
+				for i, value := range row {
+					if value == "" {
+						row[i] = "-"
+					}
+				}
+				fmt.Fprintln(writer, strings.Join(row, "\t"))
+			}
+		}
+
+		writer.Flush()
+	},
+}
+
+// listProjectsCmd represents the list projects command
+var listProjectsCmd = &cobra.Command{
+	Use:     "projects",
+	Example: `env-manager-v2 list projects`,
+	Short:   "List the configured projects with their environments and providers",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "PROJECT\tENVIRONMENTS\tPROVIDERS")
+
+		for _, project := range utils.ValidProjects {
+			projEnvironments, err := utils.GetProjectEnvironments(project)
+			if err != nil {
+				projEnvironments = []string{"-"}
+			}
+
+			providers := utils.GetCloudProvider(project, utils.ProjectProviders)
+			if len(providers) == 0 {
+				providers = []string{"-"}
+			}
+
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", project, strings.Join(projEnvironments, ","), strings.Join(providers, ","))
+		}
+
+		writer.Flush()
+	},
+}
+
+// listEnvsCmd represents the list envs command
+var listEnvsCmd = &cobra.Command{
+	Use:     "envs -p <project-name>",
+	Example: `env-manager-v2 list envs -p collection-back-end-v2.1`,
+	Short:   "List the environments of a project with their providers",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "ENVIRONMENT\tPROVIDER\tSCOPE\tKUBERNETES")
+
+		for _, projEnv := range projEnvironments {
+			envConfig := utils.GetProjectEnvironmentConfig(project, projEnv)
+
+			provider, scope := envConfig.Provider, envConfig.Scope
+			if provider == "" {
+				provider = "-"
+			}
+			if scope == "" {
+				scope = "-"
+			}
+
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", projEnv, provider, scope, strconv.FormatBool(utils.IsK8sTargetConfigured(project, projEnv)))
+		}
+
+		writer.Flush()
+	},
+}
+
+// getEnvCount returns the number of keys stored for a target, or "error" if they can't be read
+func getEnvCount(target utils.Target) string {
+	_, envFile, err := utils.LoadTarget(target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return "error"
+	}
+
+	return strconv.Itoa(len(envFile.Section("").Keys()))
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.AddCommand(listProjectsCmd)
+	listCmd.AddCommand(listEnvsCmd)
+
+	listCmd.Flags().StringP("project", "p", "", "Specify the project name (default is all projects)")
+	listCmd.Flags().Bool("count", false, "Show the number of envs and secrets stored in each environment")
+
+	listEnvsCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	listEnvsCmd.MarkFlagRequired("project")
+
+	listCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	listEnvsCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	listCmd.RegisterFlagCompletionFunc("count", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,159 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+	"gopkg.in/ini.v1"
+)
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:     "plan [flags] -f <manifest>",
+	Example: `env-manager-v2 plan -f env-manager.yaml`,
+	Short:   "Show the changes needed to make the providers match a manifest",
+	Long: `Compare the environment variables and secrets described in a YAML manifest with the ones stored in the
+provider of each project environment, and show the keys that would be created, updated and deleted by the
+apply command. Nothing is changed.
+
+The manifest lists the desired envs and secrets of each project environment. The types it sets are managed
+completely: keys that are not in the manifest are deleted. Only the keys of DigitalOcean secrets are compared,
+since they are stored encrypted. Secret values must be references to a key of the
+secrets file ("ref") or to an environment variable ("env"), so they are never written in the manifest:
+
+  secrets_file: secrets.env
+  environments:
+    - project: collection-back-end-v2.1
+      environment: prod
+      envs:
+        API_URL: https://api.example.com
+      secrets:
+        DB_PASSWORD: {ref: PROD_DB_PASSWORD}
+        API_TOKEN: {env: PROD_API_TOKEN}`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		manifestPath, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		manifestPlans, err := PlanManifest(manifestPath)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		PrintManifestPlans(manifestPlans)
+	},
+}
+
+// ManifestPlan is the comparison of a manifest target with the environment variables stored in its provider
+type ManifestPlan struct {
+	Provider utils.Provider
+	Target   utils.ManifestTarget
+	EnvFile  *ini.File
+	EnvDiff  utils.EnvDiff
+}
+
+// PlanManifest loads a manifest and compares each of its targets with the environment variables stored in
+// their providers
+func PlanManifest(manifestPath string) ([]ManifestPlan, error) {
+	manifest, err := utils.LoadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestTargets, err := manifest.GetTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	var manifestPlans []ManifestPlan
+	var managedTargets []utils.Target
+
+	for _, manifestTarget := range manifestTargets {
+		target := manifestTarget.Target
+
+		err := utils.ValidateProjectEnvironment(target.Project, target.Environment)
+		if err != nil {
+			return nil, err
+		}
+
+		provider, envFile, err := utils.LoadTarget(target)
+		if err != nil {
+			return nil, err
+		}
+
+		// Providers that don't separate secrets store both types together, so each type would delete the other
+		if otherTarget, ok := utils.FindTypelessConflict(provider, target, managedTargets); ok {
+			return nil, fmt.Errorf("%s and %s of project \"%s\" in \"%s\" environment are stored together in %s. Declare them in only one of the types", otherTarget.EnvType, target.EnvType, target.Project, target.Environment, provider.Describe(target))
+		}
+		managedTargets = append(managedTargets, target)
+
+		// DigitalOcean only returns the encrypted values of secrets, so only their keys can be compared
+		envDiff := utils.DiffEnvs(envFile, manifestTarget.Envs)
+		if utils.HasEncryptedValues(provider, target.EnvType) {
+			envDiff = utils.DiffEnvKeys(envFile, manifestTarget.Envs)
+		}
+
+		manifestPlans = append(manifestPlans, ManifestPlan{
+			Provider: provider,
+			Target:   manifestTarget,
+			EnvFile:  envFile,
+			EnvDiff:  envDiff,
+		})
+	}
+
+	return manifestPlans, nil
+}
+
+// PrintManifestPlans prints the changes of each manifest target and a summary. Returns true if there are changes.
+func PrintManifestPlans(manifestPlans []ManifestPlan) bool {
+	created, updated, deleted, changedTargets := 0, 0, 0, 0
+
+	for _, manifestPlan := range manifestPlans {
+		target := manifestPlan.Target.Target
+		description := fmt.Sprintf("%s of project \"%s\" in \"%s\" environment (%s)", target.EnvType, target.Project, target.Environment, manifestPlan.Provider.Describe(target))
+
+		envDiff := manifestPlan.EnvDiff
+		if !envDiff.HasChanges() {
+			fmt.Printf("No changes in %s\n", description)
+			printNotComparable(envDiff)
+			continue
+		}
+
+		PrintEnvChanges(description, envDiff.OnlyInRight, envDiff.Changed, envDiff.OnlyInLeft)
+		printNotComparable(envDiff)
+
+		created += len(envDiff.OnlyInRight)
+		updated += len(envDiff.Changed)
+		deleted += len(envDiff.OnlyInLeft)
+		changedTargets++
+	}
+
+	fmt.Printf("Plan: %d to create, %d to update, %d to delete in %d of %d environment variable sets\n", created, updated, deleted, changedTargets, len(manifestPlans))
+	return changedTargets > 0
+}
+
+// printNotComparable prints how many keys of a plan are DigitalOcean secrets whose values can't be compared
+func printNotComparable(envDiff utils.EnvDiff) {
+	if len(envDiff.NotComparable) > 0 {
+		fmt.Printf("  %d DigitalOcean secrets are stored encrypted, so only their keys are compared\n", len(envDiff.NotComparable))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+
+	planCmd.Flags().StringP("file", "f", "", "Specify the manifest file")
+
+	planCmd.MarkFlagRequired("file")
+
+	planCmd.RegisterFlagCompletionFunc("file", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"yaml", "yml"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+}
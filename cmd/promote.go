@@ -0,0 +1,231 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+	"gopkg.in/ini.v1"
+)
+
+// promoteCmd represents the promote command
+var promoteCmd = &cobra.Command{
+	Use:     "promote [flags] -p <project-name> -e <project-environment> --to-environment <project-environment> (<name>...|--all)",
+	Aliases: []string{"copy"},
+	Example: `env-manager-v2 promote -p collection-back-end-v2.1 -e homolog --to-environment prod foo bar
+env-manager-v2 promote -p collection-back-end-v2.1 -e homolog --to-environment prod -t secrets --all
+env-manager-v2 promote -p gollection-elastic -e dev --to-project collection-back-end-v2.1 --to-environment dev moo`,
+	Short: "Copy environment variables or secrets from one environment to another",
+	Long: `Copy environment variables or secrets from a source project environment to a target project
+environment. The source and target can use different providers. Missing keys are created in the target
+and existing keys are updated. Pass the keys to copy as arguments or use the --all flag to copy all of them.
+The target project defaults to the source project. Secrets of DigitalOcean sources can't be copied, since
+DigitalOcean encrypts them, and AWS Amplify targets store secrets as plain environment variables.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		isAll, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		if isAll && len(args) > 0 {
+			return fmt.Errorf("cannot use arguments with --all flag")
+		}
+
+		if !isAll && len(args) == 0 {
+			return fmt.Errorf("requires at least one name argument unless --all is used")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		isK8s, err := cmd.Flags().GetBool("k8s")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		projEnvironment, err := cmd.Flags().GetString("environment")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		toProject, err := cmd.Flags().GetString("to-project")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		if toProject == "" {
+			toProject = project
+		}
+
+		toEnvironment, err := cmd.Flags().GetString("to-environment")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		source := utils.Target{Project: project, Environment: projEnvironment, EnvType: envType}
+		target := utils.Target{Project: toProject, Environment: toEnvironment, EnvType: envType}
+
+		if source.Project == target.Project && source.Environment == target.Environment {
+			log.Fatalf("Error: the source and target environments are the same")
+		}
+
+		for _, t := range []utils.Target{source, target} {
+			err = utils.ValidateProjectEnvironment(t.Project, t.Environment)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+		}
+
+		sourceProvider, err := utils.GetProvider(source.Project, source.Environment)
+		if err != nil {
+			log.Fatalf("Error getting provider: %v", err)
+		}
+
+		provider, err := utils.GetProvider(target.Project, target.Environment)
+		if err != nil {
+			log.Fatalf("Error getting provider: %v", err)
+		}
+
+		if envType == "secrets" && provider.Name() == "AWS" {
+			fmt.Printf("[WARNING] AWS Amplify doesn't have secrets. The secrets will be saved as plain environment variables of %s\n", provider.Describe(target))
+		}
+
+		// With --all there are no arguments, so every environment variable is copied
+		userEnvFile, err := utils.LoadPromotedEnvs(sourceProvider, source, args)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		UpsertEnvs(provider, target, userEnvFile, isK8s, isQuiet)
+	},
+}
+
+// UpsertEnvs creates the missing environment variables of userEnvFile in a target and updates the existing ones.
+// It prints a summary of the changes and, unless isQuiet is set, asks for confirmation before saving.
+func UpsertEnvs(provider utils.Provider, target utils.Target, userEnvFile *ini.File, isK8s bool, isQuiet bool) {
+	envFile, err := provider.Load(target)
+	if err != nil {
+		fmt.Printf("Error loading %s: %v\n", provider.Describe(target), err)
+		return
+	}
+
+	createdEnvs, updatedEnvs, changedEnvs := utils.UpsertEnvironmentVariables(envFile, userEnvFile)
+	updatedEnvs = utils.AddScopeChanges(provider, target, envFile, updatedEnvs, changedEnvs)
+
+	if len(createdEnvs) == 0 && len(updatedEnvs) == 0 {
+		fmt.Printf("Environment variables already up to date in project \"%s\" in \"%s\" environment\n", target.Project, target.Environment)
+		return
+	}
+
+	PrintEnvChanges(fmt.Sprintf("project \"%s\" in \"%s\" environment (%s)", target.Project, target.Environment, provider.Describe(target)), createdEnvs, updatedEnvs, nil)
+
+	if !isQuiet && !utils.GetUserPermission("Are you sure you want to save the environment variables?") {
+		return
+	}
+
+	err = utils.SaveTarget(provider, target, envFile, isK8s, changedEnvs, nil)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printSaved("Environment variables saved in project \"%s\" in \"%s\" environment: %d created, %d updated\n", target.Project, target.Environment, len(createdEnvs), len(updatedEnvs))
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+
+	promoteCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type")
+	promoteCmd.Flags().StringP("project", "p", "", "Specify the source project name")
+	promoteCmd.Flags().StringP("environment", "e", "", "Specify the source project environment")
+	promoteCmd.Flags().String("to-project", "", "Specify the target project name (default is the source project)")
+	promoteCmd.Flags().String("to-environment", "", "Specify the target project environment")
+	promoteCmd.Flags().BoolP("all", "A", false, "Copy all environment variables or secrets")
+	promoteCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before saving the environment variables or secrets")
+	promoteCmd.Flags().BoolP("k8s", "k", false, "Create or update the environment variables or secrets in the target Kubernetes cluster")
+
+	promoteCmd.MarkFlagRequired("project")
+	promoteCmd.MarkFlagRequired("environment")
+	promoteCmd.MarkFlagRequired("to-environment")
+
+	promoteCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	promoteCmd.RegisterFlagCompletionFunc("to-project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	promoteCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	promoteCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	promoteCmd.RegisterFlagCompletionFunc("to-environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("to-project")
+		if err != nil || project == "" {
+			project, err = cmd.Flags().GetString("project")
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	promoteCmd.RegisterFlagCompletionFunc("all", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	promoteCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	promoteCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// renderCmd represents the render command
+var renderCmd = &cobra.Command{
+	Use: "render [flags] -p <project-name> -e <project-environment> -f <template>",
+	Example: `env-manager-v2 render -p collection-back-end-v2.1 -e prod -f nginx.conf.tmpl --syntax envsubst -o nginx.conf
+env-manager-v2 render -p collection-back-end-v2.1 -e dev -t all -f application.properties.tmpl
+cat values.yaml.tmpl | env-manager-v2 render -p gollection-elastic -e homolog -f - > values.yaml`,
+	Short: "Render a template with the environment variables and secrets of a project",
+	Long: `Render a template file with the environment variables or secrets of a project environment, from any
+provider, to stdout or to a file. The template syntaxes are:
+
+  go        Go text/template, as in {{ .KEY }} or {{ index . "key.with.dots" }} (default)
+  envsubst  ${KEY} references. The $KEY form is left untouched, so nginx and shell variables are kept
+
+Rendering fails when the template references a key that doesn't exist. Use "-" as the file to read stdin.
+Secret values are rendered in plain text, so be careful where the output is written.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envTypeFlag, err := cmd.Flags().GetString("type")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envTypes, err := utils.ParseEnvTypes(envTypeFlag)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		syntax, err := utils.GetFlagString(cmd, "syntax", utils.ValidTemplateSyntaxes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		filePath, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		outputPath, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		var content []byte
+		templateName := "stdin"
+		if filePath == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = os.ReadFile(filePath)
+			templateName = filepath.Base(filePath)
+		}
+		if err != nil {
+			log.Fatalf("Error reading template: %v", err)
+		}
+
+		envFile, err := utils.LoadEnvTypes(project, projEnvironment, envTypes)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		output, err := utils.RenderTemplate(templateName, content, envFile, syntax)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		if outputPath == "" {
+			os.Stdout.Write(output)
+			return
+		}
+
+		err = os.WriteFile(outputPath, output, 0600)
+		if err != nil {
+			log.Fatalf("Error writing file: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Template \"%s\" rendered with project \"%s\" in \"%s\" environment to \"%s\"\n", templateName, project, projEnvironment, outputPath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable types, separated by commas (envs, secrets or all)")
+	renderCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	renderCmd.Flags().StringP("environment", "e", "", "Specify the project environment")
+	renderCmd.Flags().StringP("file", "f", "", "Specify the template file (- for stdin)")
+	renderCmd.Flags().String("syntax", "go", fmt.Sprintf("Specify the template syntax (options: %s)", strings.Join(utils.ValidTemplateSyntaxes, ", ")))
+	renderCmd.Flags().StringP("output", "o", "", "Write to a file instead of stdout")
+
+	renderCmd.MarkFlagRequired("project")
+	renderCmd.MarkFlagRequired("environment")
+	renderCmd.MarkFlagRequired("file")
+
+	renderCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	renderCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		types = append(types, "all")
+		return types, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	renderCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	renderCmd.RegisterFlagCompletionFunc("file", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	})
+
+	renderCmd.RegisterFlagCompletionFunc("syntax", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		syntaxes := []cobra.Completion{}
+		syntaxes = append(syntaxes, utils.ValidTemplateSyntaxes...)
+		return syntaxes, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	renderCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	})
+}
@@ -0,0 +1,231 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+	"gopkg.in/ini.v1"
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use: "restore [flags] -f <file> [-p <project-name>] [-e <project-environment>]",
+	Example: `env-manager-v2 restore -f env-manager-2025-01-31.bak --list
+env-manager-v2 restore -f env-manager-2025-01-31.bak
+env-manager-v2 restore -f env-manager-2025-01-31.bak -p collection-back-end-v2.1 -e prod -t secrets -k`,
+	Short: "Restore the environment variables and secrets of a backup file",
+	Long: `Restore the environment variables and secrets of a file created by the backup command. By default every
+project environment of the backup is restored. Use the project, environment and type flags to restore only part
+of it, and the list flag to only show its contents. Each environment is restored to the provider currently
+configured for it, which can be different from the one it was backed up from. The restored variables replace the
+existing ones: the keys created, updated and deleted are shown and saved after confirmation.
+
+Providers that keep the envs and secrets of an environment together, such as AWS Amplify, are restored once with
+the variables of all the restored types. Project environments that are not configured anymore are skipped, and so
+are DigitalOcean secrets, which are backed up encrypted, unless they are restored to DigitalOcean. If some
+environment can't be restored, the others are still restored and the command exits with an error.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isK8s, err := cmd.Flags().GetBool("k8s")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isList, err := cmd.Flags().GetBool("list")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		filePath, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		projEnvironment, err := cmd.Flags().GetString("environment")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envTypeFlag, err := cmd.Flags().GetString("type")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envTypes, err := utils.ParseEnvTypes(envTypeFlag)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Fatalf("Error reading file: %v", err)
+		}
+
+		passphrase, err := utils.GetBackupPassphrase(false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		backup, err := utils.DecryptBackup(content, passphrase)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		fmt.Printf("Backup created at %s\n", backup.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+
+		restoredEntries := 0
+		failedEntries := 0
+		restoredTypeless := make(map[string]bool)
+		for _, entry := range backup.Entries {
+			if (project != "" && entry.Project != project) || (projEnvironment != "" && entry.Environment != projEnvironment) || !slices.Contains(envTypes, entry.EnvType) {
+				continue
+			}
+			restoredEntries++
+
+			if isList {
+				fmt.Printf("%d %s of project \"%s\" in \"%s\" environment, from %s\n", len(entry.Envs), entry.EnvType, entry.Project, entry.Environment, entry.Provider)
+				continue
+			}
+
+			err = RestoreBackupEntry(backup, entry, envTypes, restoredTypeless, isK8s, isQuiet)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				failedEntries++
+			}
+		}
+
+		if restoredEntries == 0 {
+			log.Fatalf("Error: no environment variables in the backup match the given project, environment and type")
+		}
+		if failedEntries > 0 {
+			log.Fatalf("Error: the restore is incomplete, %d environment variable sets couldn't be restored", failedEntries)
+		}
+	},
+}
+
+// RestoreBackupEntry replaces the environment variables of a target with the ones of a backup entry. Targets of
+// providers that keep all types together are restored once, with the entries of all the given types, and are
+// recorded in restoredTypeless.
+func RestoreBackupEntry(backup *utils.Backup, entry utils.BackupEntry, envTypes []string, restoredTypeless map[string]bool, isK8s bool, isQuiet bool) error {
+	if !utils.StringInSlice(entry.Project, utils.ValidProjects) {
+		fmt.Printf("Skipping project \"%s\": not configured\n", entry.Project)
+		return nil
+	}
+
+	err := utils.ValidateProjectEnvironment(entry.Project, entry.Environment)
+	if err != nil {
+		fmt.Printf("Skipping project \"%s\" in \"%s\" environment: %v\n", entry.Project, entry.Environment, err)
+		return nil
+	}
+
+	target := utils.Target{Project: entry.Project, Environment: entry.Environment, EnvType: entry.EnvType}
+
+	provider, envFile, err := utils.LoadTarget(target)
+	if err != nil {
+		return err
+	}
+
+	backupEnvs := entry.Envs
+	if !utils.SeparatesTypes(provider, target) {
+		typelessKey := entry.Project + "/" + entry.Environment
+		if restoredTypeless[typelessKey] {
+			return nil
+		}
+		restoredTypeless[typelessKey] = true
+
+		backupEnvs, err = backup.MergeEnvs(entry.Project, entry.Environment, envTypes)
+		if err != nil {
+			return err
+		}
+	} else if entry.EnvType == "secrets" && entry.Provider == "DGO" && provider.Name() != "DGO" {
+		// DigitalOcean only returns the encrypted values of secrets, which can't be used outside the app
+		fmt.Printf("Skipping secrets of project \"%s\" in \"%s\" environment: they were backed up encrypted by DigitalOcean\n", entry.Project, entry.Environment)
+		return nil
+	}
+
+	backupEnvFile := ini.Empty()
+	for _, envName := range slices.Sorted(maps.Keys(backupEnvs)) {
+		backupEnvFile.Section("").Key(envName).SetValue(backupEnvs[envName])
+	}
+
+	return ReplaceEnvs(provider, target, envFile, backupEnvFile, isK8s, isQuiet)
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().StringP("file", "f", "", "Specify the backup file")
+	restoreCmd.Flags().StringP("type", "t", "all", "Specify the environment variable types to restore, separated by commas (envs, secrets or all)")
+	restoreCmd.Flags().StringP("project", "p", "", "Specify the project to restore (default is all projects)")
+	restoreCmd.Flags().StringP("environment", "e", "", "Specify the project environment to restore (default is all environments)")
+	restoreCmd.Flags().Bool("list", false, "Only list the contents of the backup")
+	restoreCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before restoring each environment")
+	restoreCmd.Flags().BoolP("k8s", "k", false, "Apply the changes to the Kubernetes cluster")
+
+	restoreCmd.MarkFlagRequired("file")
+	restoreCmd.MarkFlagsMutuallyExclusive("list", "quiet")
+	restoreCmd.MarkFlagsMutuallyExclusive("list", "k8s")
+
+	restoreCmd.RegisterFlagCompletionFunc("file", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	})
+
+	restoreCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	restoreCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		types = append(types, "all")
+		return types, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	restoreCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	restoreCmd.RegisterFlagCompletionFunc("list", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	restoreCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	restoreCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
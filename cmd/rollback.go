@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use: "rollback [flags] -p <project-name> -e <project-environment> --version <version-id>",
+	Example: `env-manager-v2 rollback -p collection-back-end-v2.1 -e prod --version 5b3c4f2e-0a1d-4c6e-9f7a-2d8e1b3c4a5f
+env-manager-v2 rollback -p collection-back-end-v2.1 -e prod -t secrets --version 5b3c4f2e-0a1d-4c6e-9f7a-2d8e1b3c4a5f -k`,
+	Short: "Restore a previous version of the environment variables or secrets of a project",
+	Long: `Restore a previous version, listed by the history command, of the environment variables or secrets of a
+project environment. The keys created, updated and deleted by the rollback are shown before they are saved.
+The restored version is saved as a new version, so the rollback itself can be rolled back. Use the k8s flag to
+apply the same changes to the Kubernetes ConfigMap or Secret.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isK8s, err := cmd.Flags().GetBool("k8s")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		versionID, err := cmd.Flags().GetString("version")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		target := utils.Target{Project: project, Environment: projEnvironment, EnvType: envType}
+
+		provider, err := GetVersionedProvider(target)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		versionEnvFile, envFile, err := utils.LoadRollback(provider, target, versionID)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		err = ReplaceEnvs(provider, target, envFile, versionEnvFile, isK8s, isQuiet)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type")
+	rollbackCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	rollbackCmd.Flags().StringP("environment", "e", "", "Specify the project environment")
+	rollbackCmd.Flags().String("version", "", "Specify the version ID to restore, as listed by the history command")
+	rollbackCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before restoring the version")
+	rollbackCmd.Flags().BoolP("k8s", "k", false, "Apply the changes to the Kubernetes cluster")
+
+	rollbackCmd.MarkFlagRequired("project")
+	rollbackCmd.MarkFlagRequired("environment")
+	rollbackCmd.MarkFlagRequired("version")
+
+	rollbackCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	rollbackCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	rollbackCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	rollbackCmd.RegisterFlagCompletionFunc("version", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	rollbackCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	rollbackCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
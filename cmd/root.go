@@ -4,9 +4,14 @@ Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
 package cmd
 
 import (
+	"fmt"
+	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -20,19 +25,66 @@ environment variables and secrets in Kubernetes Cluster.
 The environment variables and secrets are stored in ConfigMap and Secret in the
 Kubernetes Cluster and stored as a key-value pair in Object Storage in OCI.
 	`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		for c := cmd; c != nil; c = c.Parent() {
+			if c.Name() == configureCmd.Name() || c.Name() == "completion" || c.Name() == "help" {
+				return
+			}
+		}
+
+		if !utils.ConfigFileExists() {
+			fatalWithExitCode(getErrorExitCode(cmd), "Config file not found. Make sure you have run the configure command or created the file manually")
+		}
+
+		// doctor reads the config file itself, to report its problems
+		if utils.ConfigGlobalsErr != nil && cmd.Name() != doctorCmd.Name() {
+			fatalWithExitCode(getErrorExitCode(cmd), "Error: %s. Run the doctor command to check the config file", strings.TrimSpace(utils.ConfigGlobalsErr.Error()))
+		}
+	},
 }
 
+// errorExitCodeAnnotation sets the exit code of a command on errors, for commands that use exit code 1 for a result
+const errorExitCodeAnnotation = "errorExitCode"
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(getErrorExitCode(cmd))
+	}
+}
+
+// getErrorExitCode returns the exit code of a command on errors, which is 1 unless set in its annotations
+func getErrorExitCode(cmd *cobra.Command) int {
+	if exitCode, err := strconv.Atoi(cmd.Annotations[errorExitCodeAnnotation]); err == nil {
+		return exitCode
+	}
+	return 1
+}
+
+// printSaved prints the message of a saved change. It's not printed in dry-run mode, where nothing is saved.
+func printSaved(format string, v ...any) {
+	if !utils.DryRun {
+		fmt.Printf(format, v...)
 	}
 }
 
+// fatalWithExitCode prints an error like log.Fatalf, but exits with the given code
+func fatalWithExitCode(exitCode int, format string, v ...any) {
+	log.Printf(format, v...)
+	os.Exit(exitCode)
+}
+
 func init() {
 	rootCmd.Version = "2.1.0"
+
+	rootCmd.PersistentFlags().BoolVar(&utils.DryRun, "dry-run", false, "Only show the changes to the providers and the Kubernetes cluster, without applying them")
+
+	rootCmd.RegisterFlagCompletionFunc("dry-run", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
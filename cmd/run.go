@@ -0,0 +1,107 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use: "run [flags] -p <project-name> -e <project-environment> -- <command> [args...]",
+	Example: `env-manager-v2 run -p collection-back-end-v2.1 -e dev -- go run .
+env-manager-v2 run -p collection-back-end-v2.1 -e dev -t all -- npm start
+env-manager-v2 run -p gollection-elastic -e homolog -t envs,secrets -- sh -c 'echo $DATABASE_URL'`,
+	Short: "Run a command with the environment variables or secrets of a project",
+	Long: `Run a command with the environment variables or secrets of a project environment, from any provider, added
+to its environment. The variables are only kept in memory and never written to disk. Variables that are already
+set in the current environment are overridden by the stored ones. The type flag accepts a comma-separated list of
+types, or all. Interrupt and termination signals are forwarded to the command, and env-manager-v2 exits with the
+exit code of the command, or with 128 plus the signal number when the command is killed by a signal.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envTypeFlag, err := cmd.Flags().GetString("type")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envTypes, err := utils.ParseEnvTypes(envTypeFlag)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envFile, err := utils.LoadEnvTypes(project, projEnvironment, envTypes)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		exitCode, err := utils.RunWithEnvs(args, envFile)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		os.Exit(exitCode)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	// Flags after the command name belong to the command
+	runCmd.Flags().SetInterspersed(false)
+
+	runCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable types, separated by commas (envs, secrets or all)")
+	runCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	runCmd.Flags().StringP("environment", "e", "", "Specify the project environment")
+
+	runCmd.MarkFlagRequired("project")
+	runCmd.MarkFlagRequired("environment")
+
+	runCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	runCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		types = append(types, "all")
+		return types, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	runCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+}
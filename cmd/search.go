@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// Exit codes of the search command
+const (
+	searchNoMatchExitCode = 1
+	searchErrorExitCode   = 2
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use: "search [flags] [--key <pattern>] [--value <substring>] [--value-sha256 <hash>]",
+	Example: `env-manager-v2 search --key 'DB_*'
+env-manager-v2 search --key '^(REDIS|CACHE)_' --regex -p collection-back-end-v2.1
+env-manager-v2 search --value old-db.internal -t envs
+env-manager-v2 search --value-sha256 $(printf '%s' "$OLD_PASSWORD" | sha256sum | cut -d' ' -f1)`,
+	Short: "Search the environment variables and secrets of all projects by key or value",
+	Long: `Search every environment of every project, in whichever provider each one uses, for the keys matching a
+glob pattern (or a regular expression with the regex flag) and for the values containing a substring or with a
+given SHA-256 hash. When more than one is given, a key must match all of them. Each match is reported with its
+project, environment and type, and values are never printed.
+
+Values can only be searched by substring in envs, so secrets are skipped. Use the SHA-256 hash of the value to
+search secrets without typing it. DigitalOcean secrets are encrypted, so they can only be searched by key.
+Providers that keep envs and secrets together, such as AWS Amplify, are searched once.
+The command exits with code 0 when something is found, 1 when nothing is found and 2 on errors.`,
+	Annotations: map[string]string{errorExitCodeAnnotation: strconv.Itoa(searchErrorExitCode)},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		keyPattern, err := cmd.Flags().GetString("key")
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		isKeyRegex, err := cmd.Flags().GetBool("regex")
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		valueSubstring, err := cmd.Flags().GetString("value")
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		valueHash, err := cmd.Flags().GetString("value-sha256")
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		envTypeFlag, err := cmd.Flags().GetString("type")
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		envTypes, err := utils.ParseEnvTypes(envTypeFlag)
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error: %v", err)
+		}
+
+		query, err := utils.NewEnvQuery(keyPattern, isKeyRegex, valueHash, valueSubstring)
+		if err != nil {
+			fatalWithExitCode(searchErrorExitCode, "Error: %v", err)
+		}
+
+		projects := utils.ValidProjects
+		if project != "" {
+			if !utils.StringInSlice(project, utils.ValidProjects) {
+				fatalWithExitCode(searchErrorExitCode, "Error: invalid project \"%s\". Options are: %v", project, utils.ValidProjects)
+			}
+			projects = []string{project}
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "PROJECT\tENVIRONMENT\tTYPE\tKEY\tLOCATION")
+
+		matches := 0
+		isError := false
+		for _, proj := range projects {
+			projEnvironments, err := utils.GetProjectEnvironments(proj)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				isError = true
+				continue
+			}
+
+			for _, projEnv := range projEnvironments {
+				provider, err := utils.GetProvider(proj, projEnv)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error getting provider of project \"%s\" in \"%s\" environment: %v\n", proj, projEnv, err)
+					isError = true
+					continue
+				}
+
+				isTypelessSearched := false
+				for _, envType := range envTypes {
+					target := utils.Target{Project: proj, Environment: projEnv, EnvType: envType}
+
+					// Secret values can't be searched by substring, and DigitalOcean only returns them encrypted
+					if envType == "secrets" && (query.IsValueQuery() || (query.ValueHash != "" && provider.Name() == "DGO")) {
+						continue
+					}
+
+					// Providers that keep all types together return the same variables for each type
+					if !utils.SeparatesTypes(provider, target) {
+						if isTypelessSearched {
+							continue
+						}
+						isTypelessSearched = true
+					}
+
+					envFile, err := provider.Load(target)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", provider.Describe(target), err)
+						isError = true
+						continue
+					}
+
+					for _, envName := range query.Match(envFile) {
+						fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", proj, projEnv, envType, envName, provider.Describe(target))
+						matches++
+					}
+				}
+			}
+		}
+
+		if matches > 0 {
+			writer.Flush()
+		}
+
+		if isError {
+			os.Exit(searchErrorExitCode)
+		}
+		if matches == 0 {
+			fmt.Fprintln(os.Stderr, "No matches found")
+			os.Exit(searchNoMatchExitCode)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().String("key", "", "Specify a glob pattern for the key names, as in 'DB_*'")
+	searchCmd.Flags().Bool("regex", false, "Use the key pattern as a regular expression")
+	searchCmd.Flags().String("value", "", "Specify a substring of the values (envs only)")
+	searchCmd.Flags().String("value-sha256", "", "Specify the hex encoded SHA-256 hash of the values")
+	searchCmd.Flags().StringP("project", "p", "", "Specify the project name (default is all projects)")
+	searchCmd.Flags().StringP("type", "t", "all", "Specify the environment variable types to search, separated by commas (envs, secrets or all)")
+
+	searchCmd.RegisterFlagCompletionFunc("key", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	searchCmd.RegisterFlagCompletionFunc("regex", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	searchCmd.RegisterFlagCompletionFunc("value", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	searchCmd.RegisterFlagCompletionFunc("value-sha256", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	searchCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	searchCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		types = append(types, "all")
+		return types, cobra.ShellCompDirectiveNoFileComp
+	})
+}
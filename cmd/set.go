@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// setCmd represents the set command
+var setCmd = &cobra.Command{
+	Use: "set [flags] -p <project-name> -e <project-environment> (-n <name> -v <value>|--file <file>)",
+	Example: `env-manager-v2 set -p collection-back-end-v2.1 -e dev -t envs -n foo -v bar
+env-manager-v2 set -p collection-back-end-v2.1 -e all -t envs -f /path/to/file --quiet
+env-manager-v2 set -p gollection-elastic -e homolog -t secrets -f /path/to/file -k`,
+	Short: "Create or update environment variables or secrets for a project",
+	Long: `Set environment variables or secrets for a configured project: the ones that don't exist yet are
+created and the existing ones are updated, with a single write to the provider. The keys that will be created
+and updated are shown before saving, after confirmation unless the quiet flag is used. Keys that already have
+the given value are left untouched, unless a DigitalOcean scope is given for them. If the file flag is used, the name and value flags are ignored. Use the
+k8s flag to set the same keys in the Kubernetes ConfigMap or Secret.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		isK8s, err := cmd.Flags().GetBool("k8s")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, true)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		filePath, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envName, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		envValue, err := cmd.Flags().GetString("value")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		scope, err := cmd.Flags().GetString("dgo-scope")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		if scope != "" && !utils.StringInSlice(scope, utils.ValidScopes) {
+			log.Fatalf("Error: invalid scope \"%s\". Options are: %v", scope, utils.ValidScopes)
+		}
+
+		projEnvironmentList := []string{projEnvironment}
+
+		if projEnvironment == "all" {
+			projEnvironmentList = utils.GetAllEnvironments(project, projEnvironments)
+		}
+
+		for _, projEnv := range projEnvironmentList {
+			target := utils.Target{Project: project, Environment: projEnv, EnvType: envType}
+
+			provider, err := utils.GetProvider(project, projEnv)
+			if err != nil {
+				fmt.Println("Error getting provider: ", err)
+				return
+			}
+
+			userEnvFile, err := utils.GetUserEnvs(filePath, envName, envValue)
+			if err != nil {
+				fmt.Println("Error loading file: ", err)
+				return
+			}
+
+			target.VariableScopes, err = utils.GetUserVariableScopes(userEnvFile, scope)
+			if err != nil {
+				fmt.Println("Error loading file: ", err)
+				return
+			}
+
+			UpsertEnvs(provider, target, userEnvFile, isK8s, isQuiet)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(setCmd)
+
+	setCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type")
+	setCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	setCmd.Flags().StringP("environment", "e", "", "Specify the project environment (or all)")
+	setCmd.Flags().StringP("name", "n", "", "Specify the environment variable or secret name (required if --file is not used)")
+	setCmd.Flags().StringP("value", "v", "", "Specify the environment variable or secret value (required if --file is not used)")
+	setCmd.Flags().StringP("file", "f", "", "Specify a file containing a list of environment variables or secrets. The file should be in INI format. (required if --name and --value are not used)")
+	setCmd.Flags().String("dgo-scope", "", "Specify the scope of the given DigitalOcean variables (options: RUN_TIME, BUILD_TIME, RUN_AND_BUILD_TIME). In a file, variables in a section named after a scope get that scope")
+	setCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before saving the environment variables or secrets")
+	setCmd.Flags().BoolP("k8s", "k", false, "Create or update the environment variables or secrets in the Kubernetes cluster")
+
+	setCmd.MarkFlagsRequiredTogether("name", "value")
+	setCmd.MarkFlagsMutuallyExclusive("file", "name")
+	setCmd.MarkFlagsMutuallyExclusive("file", "value")
+	setCmd.MarkFlagsOneRequired("file", "name")
+	setCmd.MarkFlagsOneRequired("file", "value")
+
+	setCmd.MarkFlagRequired("project")
+	setCmd.MarkFlagRequired("environment")
+
+	setCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	setCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	setCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		validEnvs = append(validEnvs, "all")
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	setCmd.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	setCmd.RegisterFlagCompletionFunc("value", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	setCmd.RegisterFlagCompletionFunc("file", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	})
+
+	setCmd.RegisterFlagCompletionFunc("dgo-scope", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		scopes := []cobra.Completion{}
+		scopes = append(scopes, utils.ValidScopes...)
+		return scopes, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	setCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	setCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// Exit codes of the status command
+const (
+	statusDriftExitCode = 1
+	statusErrorExitCode = 2
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:     "status [flags] [-p <project-name>] [-e <project-environment>]",
+	Aliases: []string{"drift"},
+	Example: `env-manager-v2 status
+env-manager-v2 status -p collection-back-end-v2.1
+env-manager-v2 drift -p collection-back-end-v2.1 -e prod`,
+	Short: "Detect drift between the stored variables and the Kubernetes ConfigMaps and Secrets",
+	Long: `Compare the environment variables and secrets stored in the provider of each project environment
+with its Kubernetes ConfigMap and Secret. Only environments with "namespace", "configmap_name" and
+"secret_name" configured are checked. By default all projects and environments are checked. It reports the
+keys missing in the cluster, the extra keys in the cluster and the keys with different values. Secret
+values are never printed, and only the keys of DigitalOcean secrets are compared, since they are encrypted. The command exits with code 0 when there is no drift, 1 when drift is found and
+2 on errors, so it can be used in CI jobs.`,
+	Annotations: map[string]string{errorExitCodeAnnotation: strconv.Itoa(statusErrorExitCode)},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			fatalWithExitCode(statusErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		projEnvironment, err := cmd.Flags().GetString("environment")
+		if err != nil {
+			fatalWithExitCode(statusErrorExitCode, "Error reading option flag: %v", err)
+		}
+
+		projects := utils.ValidProjects
+		if project != "" {
+			projects = []string{project}
+		}
+
+		isDrift := false
+		isError := false
+		checkedTargets := 0
+
+		if project != "" && !utils.StringInSlice(project, utils.ValidProjects) {
+			fatalWithExitCode(statusErrorExitCode, "Error: invalid project \"%s\". Options are: %v", project, utils.ValidProjects)
+		}
+
+		if project != "" && projEnvironment != "" {
+			err = utils.ValidateProjectEnvironment(project, projEnvironment)
+			if err != nil {
+				fatalWithExitCode(statusErrorExitCode, "Error: %v", err)
+			}
+		}
+
+		for _, proj := range projects {
+			projEnvironments, err := utils.GetProjectEnvironments(proj)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				isError = true
+				continue
+			}
+
+			for _, projEnv := range projEnvironments {
+				if projEnvironment != "" && projEnv != projEnvironment {
+					continue
+				}
+
+				if !utils.IsK8sTargetConfigured(proj, projEnv) {
+					continue
+				}
+
+				for _, envType := range utils.ValidTypes {
+					checkedTargets++
+
+					isTargetDrift, err := PrintEnvDrift(utils.Target{Project: proj, Environment: projEnv, EnvType: envType})
+					if err != nil {
+						fmt.Printf("Error checking %s of project \"%s\" in \"%s\" environment: %v\n", envType, proj, projEnv, err)
+						isError = true
+					}
+					isDrift = isDrift || isTargetDrift
+				}
+			}
+		}
+
+		if checkedTargets == 0 {
+			fatalWithExitCode(statusErrorExitCode, "Error: no environment with Kubernetes resources configured")
+		}
+
+		if isError {
+			os.Exit(statusErrorExitCode)
+		}
+		if isDrift {
+			os.Exit(statusDriftExitCode)
+		}
+	},
+}
+
+// PrintEnvDrift compares the environment variables stored for a target with its Kubernetes ConfigMap or Secret
+// and prints the differences. Returns true if they differ.
+func PrintEnvDrift(target utils.Target) (bool, error) {
+	provider, envFile, err := utils.LoadTarget(target)
+	if err != nil {
+		return false, err
+	}
+
+	k8sEnvFile, err := utils.LoadK8sTarget(target)
+	if err != nil {
+		return false, fmt.Errorf("error loading %s: %w", utils.DescribeK8sTarget(target), err)
+	}
+
+	envDiff := utils.DiffK8sDrift(provider, target, envFile, k8sEnvFile)
+	if !envDiff.HasChanges() {
+		fmt.Printf("[OK] %s matches %s\n", utils.DescribeK8sTarget(target), provider.Describe(target))
+		return false, nil
+	}
+
+	fmt.Printf("[DRIFT] %s differs from %s\n", utils.DescribeK8sTarget(target), provider.Describe(target))
+	for _, envName := range envDiff.OnlyInLeft {
+		fmt.Printf("  missing in cluster: %s\n", envName)
+	}
+	for _, envName := range envDiff.OnlyInRight {
+		fmt.Printf("  extra in cluster: %s\n", envName)
+	}
+	for _, envName := range envDiff.Changed {
+		fmt.Printf("  different value: %s\n", envName)
+	}
+
+	return true, nil
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringP("project", "p", "", "Specify the project name (default is all projects)")
+	statusCmd.Flags().StringP("environment", "e", "", "Specify the project environment (default is all environments)")
+
+	statusCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	statusCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+}
@@ -0,0 +1,192 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stanyzra/env-manager-v2/internal/utils"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use: "sync [flags] -p <project-name> -e <project-environment>",
+	Example: `env-manager-v2 sync -p collection-back-end-v2.1 -e dev
+env-manager-v2 sync -p collection-back-end-v2.1 -e prod -t secrets --dry-run
+env-manager-v2 sync -p collection-back-end-v2.1 -e all -t all --quiet`,
+	Short: "Make the Kubernetes ConfigMap or Secret match the stored environment variables or secrets",
+	Long: `Make the Kubernetes ConfigMap (for envs) or Secret (for secrets) of a project environment match
+exactly the environment variables or secrets stored in its provider. Missing keys are added, keys with
+different values are updated and keys that are not stored in the provider are removed from the cluster.
+The changes are shown before they are applied. Use the --dry-run flag to only show them. Secrets of
+DigitalOcean apps can't be synced, since DigitalOcean only returns their encrypted values.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		isQuiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		isDryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		project, err := utils.GetFlagString(cmd, "project", utils.ValidProjects, false)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		envType, err := utils.GetFlagString(cmd, "type", utils.ValidTypes, true)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironments, err := utils.GetProjectEnvironments(project)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironment, err := utils.GetFlagString(cmd, "environment", projEnvironments, true)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		projEnvironmentList := []string{projEnvironment}
+		if projEnvironment == "all" {
+			projEnvironmentList = utils.GetAllEnvironments(project, projEnvironments)
+		}
+
+		envTypeList := []string{envType}
+		if envType == "all" {
+			envTypeList = utils.ValidTypes
+		}
+
+		for _, projEnv := range projEnvironmentList {
+			if !utils.IsK8sTargetConfigured(project, projEnv) {
+				if projEnvironment == "all" {
+					fmt.Printf("Skipping project \"%s\" in \"%s\" environment: Kubernetes resources not configured\n", project, projEnv)
+					continue
+				}
+				log.Fatalf("Error: set \"%s.namespace\", \"%s.configmap_name\" and \"%s.secret_name\" of project \"%s\" to sync it", projEnv, projEnv, projEnv, project)
+			}
+
+			for _, t := range envTypeList {
+				SyncEnvs(utils.Target{Project: project, Environment: projEnv, EnvType: t}, isDryRun, isQuiet)
+			}
+		}
+	},
+}
+
+// SyncEnvs makes the Kubernetes ConfigMap or Secret of a target match the environment variables stored in its provider
+func SyncEnvs(target utils.Target, isDryRun bool, isQuiet bool) {
+	provider, err := utils.GetProvider(target.Project, target.Environment)
+	if err != nil {
+		fmt.Printf("Error getting provider: %v\n", err)
+		return
+	}
+
+	// DigitalOcean only returns the encrypted values of secrets, which would replace the real values in the cluster
+	if utils.HasEncryptedValues(provider, target.EnvType) {
+		fmt.Printf("Error: secrets of %s are encrypted by DigitalOcean and can't be synced to %s\n", provider.Describe(target), utils.DescribeK8sTarget(target))
+		return
+	}
+
+	envFile, err := provider.Load(target)
+	if err != nil {
+		fmt.Printf("Error loading %s: %v\n", provider.Describe(target), err)
+		return
+	}
+
+	k8sEnvFile, err := utils.LoadK8sTarget(target)
+	if err != nil {
+		fmt.Printf("Error loading %s: %v\n", utils.DescribeK8sTarget(target), err)
+		return
+	}
+
+	envDiff := utils.DiffEnvs(envFile, k8sEnvFile)
+	if !envDiff.HasChanges() {
+		fmt.Printf("%s is in sync with %s\n", utils.DescribeK8sTarget(target), provider.Describe(target))
+		return
+	}
+
+	PrintEnvChanges(fmt.Sprintf("%s from %s", utils.DescribeK8sTarget(target), provider.Describe(target)), envDiff.OnlyInLeft, envDiff.Changed, envDiff.OnlyInRight)
+
+	if isDryRun {
+		return
+	}
+
+	if !isQuiet && !utils.GetUserPermission("Are you sure you want to sync the Kubernetes resource?") {
+		return
+	}
+
+	err = utils.ReplaceK8sTarget(target, envFile)
+	if err != nil {
+		fmt.Printf("Error syncing %s: %v\n", utils.DescribeK8sTarget(target), err)
+		return
+	}
+	fmt.Printf("%s synced: %d added, %d updated, %d removed\n", utils.DescribeK8sTarget(target), len(envDiff.OnlyInLeft), len(envDiff.Changed), len(envDiff.OnlyInRight))
+}
+
+// PrintEnvChanges prints the keys that are created, updated and deleted in a target
+func PrintEnvChanges(description string, createdEnvs []string, updatedEnvs []string, deletedEnvs []string) {
+	fmt.Printf("Changes in %s:\n", description)
+	for _, envName := range createdEnvs {
+		fmt.Printf("  + %s (created)\n", envName)
+	}
+	for _, envName := range updatedEnvs {
+		fmt.Printf("  ~ %s (updated)\n", envName)
+	}
+	for _, envName := range deletedEnvs {
+		fmt.Printf("  - %s (deleted)\n", envName)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().StringP("type", "t", "envs", "Specify the environment variable type (envs, secrets or all)")
+	syncCmd.Flags().StringP("project", "p", "", "Specify the project name")
+	syncCmd.Flags().StringP("environment", "e", "", "Specify the project environment (or all)")
+	syncCmd.Flags().Bool("quiet", false, "Don't ask for confirmation before syncing")
+
+	syncCmd.MarkFlagRequired("project")
+	syncCmd.MarkFlagRequired("environment")
+
+	syncCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		projects := []cobra.Completion{}
+		projects = append(projects, utils.ValidProjects...)
+		return projects, cobra.ShellCompDirectiveDefault
+	})
+
+	syncCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types := []cobra.Completion{}
+		types = append(types, utils.ValidTypes...)
+		types = append(types, "all")
+		return types, cobra.ShellCompDirectiveDefault
+	})
+
+	syncCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		envs, err := utils.GetConfigProperty(project, "environments")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		validEnvs := []cobra.Completion{}
+		validEnvs = append(validEnvs, strings.Split(envs, ",")...)
+		validEnvs = append(validEnvs, "all")
+		return validEnvs, cobra.ShellCompDirectiveDefault
+	})
+
+	syncCmd.RegisterFlagCompletionFunc("quiet", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -6,12 +6,9 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/service/amplify"
-	"github.com/digitalocean/godo"
-	"github.com/oracle/oci-go-sdk/example/helpers"
-	"github.com/oracle/oci-go-sdk/v49/objectstorage"
 	"github.com/spf13/cobra"
 	"github.com/stanyzra/env-manager-v2/internal/utils"
 	"gopkg.in/ini.v1"
@@ -72,200 +69,65 @@ env-manager-v2 update -p collection-back-end-v2.1 -e dev -t envs -f /path/to/fil
 			log.Fatalf("Error reading option flag: %v", err)
 		}
 
+		scope, err := cmd.Flags().GetString("dgo-scope")
+		if err != nil {
+			log.Fatalf("Error reading option flag: %v", err)
+		}
+
+		if scope != "" && !utils.StringInSlice(scope, utils.ValidScopes) {
+			log.Fatalf("Error: invalid scope \"%s\". Options are: %v", scope, utils.ValidScopes)
+		}
+
 		projEnvironmentList := []string{projEnvironment}
 
 		if projEnvironment == "all" {
-			projEnvironmentList = utils.ValidEnvs
+			projEnvironmentList = utils.GetAllEnvironments(project, utils.ValidEnvs)
 		}
 		for _, projEnv := range projEnvironmentList {
-			provider, err := utils.GetConfigProperty(project, projEnv+".provider")
+			target := utils.Target{Project: project, Environment: projEnv, EnvType: envType}
 
+			provider, err := utils.GetProvider(project, projEnv)
 			if err != nil {
 				fmt.Println("Error getting provider: ", err)
 				return
 			}
 
-			switch provider {
-			case "OCI":
-				fileName := fmt.Sprintf("%s_%s", projEnv, envType)
-
-				configProvider, _, err := utils.GetConfigProviderOCI()
-
-				if err != nil {
-					fmt.Println("Error getting config provider: ", err)
-					return
-				}
-
-				ociNamespace, err := utils.GetConfigProperty("OCI", "namespace")
-
-				if err != nil {
-					fmt.Println("Error getting namespace: ", err)
-					return
-				}
-
-				client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(configProvider)
-				helpers.FatalIfError(err)
-
-				if filePath != "" {
-					UpdateEnvFromFile(client, ociNamespace, project, projEnv, envType, fileName, filePath, isK8s)
-				} else {
-					UpdateSingleEnv(client, ociNamespace, project, projEnv, envType, envName, envValue, fileName, isK8s)
-				}
-
-			case "AWS":
-				projEnv, err = utils.GetConfigProperty(project, projEnv+".branch_name")
-
-				if err != nil {
-					fmt.Println("Error getting project environment: ", err)
-					return
-				}
-
-				configProvider, _, err := utils.GetConfigProviderAWS()
-				if err != nil {
-					fmt.Println("Error getting config provider: ", err)
-					return
-				}
-
-				client := amplify.NewFromConfig(configProvider)
-				utils.HandleAWS(client, project, projEnv, false, filePath, args, envName, envValue, false, cmd.Name())
-
-			case "DGO":
-				client, err := utils.GetClientDGO()
-				if err != nil {
-					fmt.Println("Error getting client: ", err)
-					return
-				}
-
-				UpdateDGOEnv(client, project, filePath, projEnv, envName, envValue)
-
-			default:
-				fmt.Println("Invalid provider")
+			userEnvFile, err := utils.GetUserEnvs(filePath, envName, envValue)
+			if err != nil {
+				fmt.Println("Error loading file: ", err)
 				return
 			}
-		}
-	},
-}
-
-func UpdateDGOEnv(client *godo.Client, project string, filePath string, projEnvironment string, envName string, envValue string) {
-	dgoAppName, err := utils.GetConfigProperty(project, projEnvironment+".app_name")
-
-	if err != nil {
-		fmt.Println("Error getting app name: ", err)
-		return
-	}
-
-	dgoApp := utils.GetDGOApp(client, dgoAppName)
-	isSaved := false
-
-	updateEnvs := func(component *godo.AppStaticSiteSpec) (bool, error) {
-		envsAsIni := utils.GetDGOEnvsAsIni(component.Envs)
-
-		if filePath == "" {
-			if !envsAsIni.Section("").HasKey(envName) {
-				fmt.Printf("[WARNING] Environment variable \"%s\" doesn't exists in project \"%s\" in \"%s\" environment\n", envName, project, projEnvironment)
-				return false, nil
-			}
 
-			envsAsIni.Section("").Key(envName).SetValue(envValue)
-			isSaved = true
-		} else {
-			userEnvsAsIni, err := ini.Load(filePath)
+			target.VariableScopes, err = utils.GetUserVariableScopes(userEnvFile, scope)
 			if err != nil {
 				fmt.Println("Error loading file: ", err)
-				return false, err
+				return
 			}
 
-			isSaved, _ = utils.UpdateEnvironmentVariables(envsAsIni, userEnvsAsIni)
-		}
-
-		if isSaved {
-			component.Envs = utils.GetDGOEnvsFromIni(envsAsIni)
+			UpdateEnvs(provider, target, userEnvFile, isK8s)
 		}
-
-		return isSaved, nil
-	}
-
-	err = utils.UpdateDGOApp(client, project, dgoApp, updateEnvs)
-	if err != nil {
-		fmt.Println("Error updating app: ", err)
-		return
-	}
-
-	if isSaved {
-		fmt.Printf("Environment variables saved in project \"%s\" in \"%s\" environment\n", project, projEnvironment)
-	}
+	},
 }
 
-func UpdateEnvFromFile(client objectstorage.ObjectStorageClient, ociNamespace string, project string, projEnvironment string, envType string, fileName string, filePath string, isK8s bool) {
-	userEnvFile, err := ini.Load(filePath)
-	if err != nil {
-		fmt.Println("Error loading file: ", err)
-		return
-	}
-
-	envFile, err := utils.GetEnvsFileAsIni(project, fileName, client, ociNamespace, utils.BucketName)
-
+// UpdateEnvs updates the environment variables of userEnvFile that already exist in a target
+func UpdateEnvs(provider utils.Provider, target utils.Target, userEnvFile *ini.File, isK8s bool) {
+	envFile, err := provider.Load(target)
 	if err != nil {
-		fmt.Println("Error loading file: ", err)
+		fmt.Printf("Error loading %s: %v\n", provider.Describe(target), err)
 		return
 	}
 
 	isSaved, updatedEnvs := utils.UpdateEnvironmentVariables(envFile, userEnvFile)
+	utils.KeepVariableScopes(target.VariableScopes, updatedEnvs)
 
 	if isSaved {
-		if isK8s {
-			k8sClient, err := utils.GetK8sClient()
-			if err != nil {
-				log.Fatalf("Error getting Kubernetes client: %v", err)
-			}
-			manager, resourceName := utils.GetK8sResourceDataParams(k8sClient, project, projEnvironment, envType)
-
-			err = utils.UpdateK8sResourceData(manager, updatedEnvs, resourceName)
-
-			if err != nil {
-				log.Fatalf("Failed to update resource data: %v", err)
-			}
-		}
-		utils.SaveEnvFile(client, ociNamespace, project, fileName, envFile, utils.BucketName)
-		fmt.Printf("Environment variables saved in project \"%s\" in \"%s\" environment\n", project, projEnvironment)
-	}
-
-}
-
-func UpdateSingleEnv(client objectstorage.ObjectStorageClient, ociNamespace string, project string, projEnvironment string, envType string, envName string, envValue string, fileName string, isK8s bool) {
-	envFile, err := utils.GetEnvsFileAsIni(project, fileName, client, ociNamespace, utils.BucketName)
-
-	if err != nil {
-		fmt.Println("Error loading file: ", err)
-		return
-	}
-
-	if !envFile.Section("").HasKey(envName) {
-		fmt.Printf("[WARNING] Environment variable \"%s\" doesn't exists in project \"%s\" in \"%s\" environment\n", envName, project, projEnvironment)
-		return
-	}
-
-	if isK8s {
-		k8sClient, err := utils.GetK8sClient()
+		err = utils.SaveTarget(provider, target, envFile, isK8s, updatedEnvs, nil)
 		if err != nil {
-			log.Fatalf("Error getting Kubernetes client: %v", err)
-		}
-		manager, resourceName := utils.GetK8sResourceDataParams(k8sClient, project, projEnvironment, envType)
-
-		envsAsIni := ini.Empty()
-		envsAsIni.Section("").Key(envName).SetValue(envValue)
-
-		err = utils.UpdateK8sResourceData(manager, envsAsIni, resourceName)
-
-		if err != nil {
-			log.Fatalf("Failed to update resource data: %v", err)
+			fmt.Printf("Error: %v\n", err)
+			return
 		}
+		printSaved("Environment variables saved in project \"%s\" in \"%s\" environment\n", target.Project, target.Environment)
 	}
-
-	envFile.Section("").Key(envName).SetValue(envValue)
-	utils.SaveEnvFile(client, ociNamespace, project, fileName, envFile, utils.BucketName)
-	fmt.Printf("Environment variables saved in project \"%s\" in \"%s\" environment\n", project, projEnvironment)
-
 }
 
 func init() {
@@ -273,7 +135,7 @@ func init() {
 
 	validTypesStr := strings.Join(utils.ValidTypes, ", ")
 	validProjectsStr := strings.Join(utils.ValidProjects, ", ")
-	validProjectEnvsStr := strings.Join(append(utils.ValidEnvs, "all"), ", ")
+	validProjectEnvsStr := strings.Join(slices.Concat(utils.ValidEnvs, []string{"all"}), ", ")
 
 	updateCmd.Flags().StringP("type", "t", "envs", fmt.Sprintf("Specify the environment variable type (options: %s)", validTypesStr))
 	updateCmd.Flags().StringP("project", "p", "", fmt.Sprintf("Specify the project name (options: %s)", validProjectsStr))
@@ -281,6 +143,7 @@ func init() {
 	updateCmd.Flags().StringP("name", "n", "", "Specify the environment variable or secret name")
 	updateCmd.Flags().StringP("value", "v", "", "Specify the environment variable or secret value")
 	updateCmd.Flags().StringP("file", "f", "", "Specify a file containing a list of environment variables or secrets. The file should be in INI format.")
+	updateCmd.Flags().String("dgo-scope", "", "Specify the scope of the given DigitalOcean variables (options: RUN_TIME, BUILD_TIME, RUN_AND_BUILD_TIME). In a file, variables in a section named after a scope get that scope")
 	updateCmd.Flags().BoolP("k8s", "k", false, "Update the environment variable or secret from the Kubernetes cluster")
 
 	updateCmd.MarkFlagsRequiredTogether("name", "value")
@@ -331,6 +194,12 @@ func init() {
 		return nil, cobra.ShellCompDirectiveDefault
 	})
 
+	updateCmd.RegisterFlagCompletionFunc("dgo-scope", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		scopes := []cobra.Completion{}
+		scopes = append(scopes, utils.ValidScopes...)
+		return scopes, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	updateCmd.RegisterFlagCompletionFunc("k8s", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	})
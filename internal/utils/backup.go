@@ -0,0 +1,219 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+// BackupPassphraseEnv is the environment variable read for the backup passphrase before asking for it
+const BackupPassphraseEnv = "ENV_MANAGER_BACKUP_PASSPHRASE"
+
+// backupMagic identifies the backup files and the version of their format
+var backupMagic = []byte("EMV2BAK1")
+
+// Parameters of the scrypt key derivation and sizes of the backup file header
+const (
+	backupScryptN  = 1 << 15
+	backupScryptR  = 8
+	backupScryptP  = 1
+	backupKeySize  = 32
+	backupSaltSize = 16
+)
+
+// Backup is a copy of the environment variables of several project environments
+type Backup struct {
+	CreatedAt time.Time     `json:"created_at"`
+	Entries   []BackupEntry `json:"entries"`
+}
+
+// BackupEntry is a copy of a target, with the provider it was read from
+type BackupEntry struct {
+	Project     string            `json:"project"`
+	Environment string            `json:"environment"`
+	EnvType     string            `json:"type"`
+	Provider    string            `json:"provider"`
+	Envs        map[string]string `json:"envs"`
+}
+
+// MergeEnvs merges the entries of a project environment with the given types into a single set, for providers that
+// keep all types together. DigitalOcean secrets are left out, since they are backed up encrypted. A key can only be in
+// more than one entry with the same value.
+func (b *Backup) MergeEnvs(project string, projEnvironment string, envTypes []string) (map[string]string, error) {
+	mergedEnvs := make(map[string]string)
+	for _, entry := range b.Entries {
+		if entry.Project != project || entry.Environment != projEnvironment || !slices.Contains(envTypes, entry.EnvType) {
+			continue
+		}
+		if entry.EnvType == "secrets" && entry.Provider == "DGO" {
+			continue
+		}
+
+		for envName, value := range entry.Envs {
+			if mergedValue, ok := mergedEnvs[envName]; ok && mergedValue != value {
+				return nil, fmt.Errorf("\"%s\" is backed up with different values in more than one type of project \"%s\" in \"%s\" environment", envName, project, projEnvironment)
+			}
+			mergedEnvs[envName] = value
+		}
+	}
+
+	return mergedEnvs, nil
+}
+
+// WriteBackupFile writes an encrypted backup to a file readable only by the user, also when it overwrites an existing
+// file, whose permissions os.WriteFile keeps
+func WriteBackupFile(filePath string, data []byte) error {
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Restrict the permissions before writing, so the backup is never readable by others
+	err = file.Chmod(0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// EncryptBackup encrypts a backup with AES-256-GCM, using a key derived from the passphrase with scrypt. The file
+// has the format version, the scrypt salt, the GCM nonce and the encrypted JSON of the backup.
+func EncryptBackup(backup *Backup, passphrase []byte) ([]byte, error) {
+	plaintext, err := json.Marshal(backup)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding backup: %w", err)
+	}
+
+	salt := make([]byte, backupSaltSize)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+
+	gcm, err := getBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	header := append(append(append([]byte{}, backupMagic...), salt...), nonce...)
+	// The header is authenticated with the backup, so it can't be changed without failing the decryption
+	return gcm.Seal(header, nonce, plaintext, header), nil
+}
+
+// DecryptBackup decrypts a backup created by EncryptBackup
+func DecryptBackup(data []byte, passphrase []byte) (*Backup, error) {
+	if !bytes.HasPrefix(data, backupMagic) {
+		return nil, errors.New("not an env-manager-v2 backup file")
+	}
+
+	if len(data) < len(backupMagic)+backupSaltSize {
+		return nil, errors.New("backup file is truncated")
+	}
+	salt := data[len(backupMagic) : len(backupMagic)+backupSaltSize]
+
+	gcm, err := getBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	headerSize := len(backupMagic) + backupSaltSize + gcm.NonceSize()
+	if len(data) < headerSize {
+		return nil, errors.New("backup file is truncated")
+	}
+	header := data[:headerSize]
+	nonce := data[len(backupMagic)+backupSaltSize : headerSize]
+
+	plaintext, err := gcm.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or corrupted backup file")
+	}
+
+	var backup Backup
+	err = json.Unmarshal(plaintext, &backup)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding backup: %w", err)
+	}
+
+	return &backup, nil
+}
+
+// getBackupCipher derives the backup key from a passphrase and returns its AES-GCM cipher
+func getBackupCipher(passphrase []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, backupScryptN, backupScryptR, backupScryptP, backupKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// GetBackupPassphrase returns the passphrase set in BackupPassphraseEnv, or asks the user for it without echoing it.
+// With isConfirmed, the user has to type it twice.
+func GetBackupPassphrase(isConfirmed bool) ([]byte, error) {
+	if passphrase := os.Getenv(BackupPassphraseEnv); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return nil, fmt.Errorf("set the passphrase in %s when the input is not a terminal", BackupPassphraseEnv)
+	}
+
+	fmt.Print("Backup passphrase: ")
+	passphrase, err := term.ReadPassword(stdin)
+	fmt.Println()
+	if err != nil {
+		return nil, fmt.Errorf("error reading passphrase: %w", err)
+	}
+
+	if len(passphrase) == 0 {
+		return nil, errors.New("the passphrase can't be empty")
+	}
+
+	if isConfirmed {
+		fmt.Print("Confirm the backup passphrase: ")
+		confirmation, err := term.ReadPassword(stdin)
+		fmt.Println()
+		if err != nil {
+			return nil, fmt.Errorf("error reading passphrase: %w", err)
+		}
+
+		if !bytes.Equal(passphrase, confirmation) {
+			return nil, errors.New("the passphrases don't match")
+		}
+	}
+
+	return passphrase, nil
+}
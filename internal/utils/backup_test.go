@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupEncryption(t *testing.T) {
+	backup := &Backup{
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Entries: []BackupEntry{
+			{Project: "proj", Environment: "dev", EnvType: "envs", Provider: "OCI", Envs: map[string]string{"A": "1"}},
+			{Project: "proj", Environment: "dev", EnvType: "secrets", Provider: "OCI", Envs: map[string]string{"TOKEN": "s3cr3t\nline"}},
+		},
+	}
+
+	encrypted, err := EncryptBackup(backup, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(encrypted, []byte("s3cr3t")) {
+		t.Fatal("EncryptBackup() output contains a secret value")
+	}
+
+	tamperedHeader := bytes.Clone(encrypted)
+	tamperedHeader[len(backupMagic)] ^= 1
+	tamperedBody := bytes.Clone(encrypted)
+	tamperedBody[len(tamperedBody)-1] ^= 1
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		wantErr    bool
+	}{
+		{name: "right passphrase", data: encrypted, passphrase: "passphrase"},
+		{name: "wrong passphrase", data: encrypted, passphrase: "other", wantErr: true},
+		{name: "tampered header", data: tamperedHeader, passphrase: "passphrase", wantErr: true},
+		{name: "tampered body", data: tamperedBody, passphrase: "passphrase", wantErr: true},
+		{name: "truncated", data: encrypted[:len(backupMagic)+4], passphrase: "passphrase", wantErr: true},
+		{name: "not a backup", data: []byte("A=1\n"), passphrase: "passphrase", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecryptBackup(tt.data, []byte(tt.passphrase))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecryptBackup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !got.CreatedAt.Equal(backup.CreatedAt) || len(got.Entries) != len(backup.Entries) {
+				t.Fatalf("DecryptBackup() = %+v, want %+v", got, backup)
+			}
+			for i, entry := range got.Entries {
+				want := backup.Entries[i]
+				if entry.Project != want.Project || entry.Environment != want.Environment || entry.EnvType != want.EnvType ||
+					entry.Provider != want.Provider || !maps.Equal(entry.Envs, want.Envs) {
+					t.Errorf("DecryptBackup() entry %d = %+v, want %+v", i, entry, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptBackupSalt(t *testing.T) {
+	backup := &Backup{}
+
+	first, err := EncryptBackup(backup, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := EncryptBackup(backup, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(first, second) {
+		t.Error("EncryptBackup() returned the same output twice, want a random salt and nonce")
+	}
+}
+
+func TestBackupMergeEnvs(t *testing.T) {
+	backup := &Backup{Entries: []BackupEntry{
+		{Project: "p1", Environment: "dev", EnvType: "envs", Provider: "OCI", Envs: map[string]string{"A": "1", "SHARED": "s"}},
+		{Project: "p1", Environment: "dev", EnvType: "secrets", Provider: "OCI", Envs: map[string]string{"TOKEN": "t", "SHARED": "s"}},
+		{Project: "p1", Environment: "prod", EnvType: "envs", Provider: "OCI", Envs: map[string]string{"A": "prod"}},
+		{Project: "p1", Environment: "qa", EnvType: "envs", Provider: "DGO", Envs: map[string]string{"A": "1"}},
+		{Project: "p1", Environment: "qa", EnvType: "secrets", Provider: "DGO", Envs: map[string]string{"TOKEN": "EV[1:abc]"}},
+		{Project: "p1", Environment: "stg", EnvType: "envs", Provider: "OCI", Envs: map[string]string{"A": "1"}},
+		{Project: "p1", Environment: "stg", EnvType: "secrets", Provider: "OCI", Envs: map[string]string{"A": "2"}},
+	}}
+
+	tests := []struct {
+		name        string
+		environment string
+		envTypes    []string
+		want        map[string]string
+		wantErr     bool
+	}{
+		{name: "all types", environment: "dev", envTypes: []string{"envs", "secrets"}, want: map[string]string{"A": "1", "SHARED": "s", "TOKEN": "t"}},
+		{name: "given types", environment: "dev", envTypes: []string{"secrets"}, want: map[string]string{"SHARED": "s", "TOKEN": "t"}},
+		{name: "DigitalOcean secrets are left out", environment: "qa", envTypes: []string{"envs", "secrets"}, want: map[string]string{"A": "1"}},
+		{name: "different values", environment: "stg", envTypes: []string{"envs", "secrets"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := backup.MergeEnvs("p1", tt.environment, tt.envTypes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MergeEnvs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !maps.Equal(got, tt.want) {
+				t.Errorf("MergeEnvs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteBackupFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "env-manager.bak")
+	err := os.WriteFile(filePath, []byte("old backup"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = WriteBackupFile(filePath, []byte("new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("WriteBackupFile() permissions = %v, want 0600", info.Mode().Perm())
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "new" {
+		t.Errorf("WriteBackupFile() content = %q, want %q", content, "new")
+	}
+}
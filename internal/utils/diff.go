@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"gopkg.in/ini.v1"
+)
+
+// EnvDiff holds the keys that differ between two sets of environment variables
+type EnvDiff struct {
+	OnlyInLeft  []string
+	OnlyInRight []string
+	Changed     []string
+	Identical   []string
+	// NotComparable are the keys in both sets whose values can't be compared, such as encrypted secrets
+	NotComparable []string
+}
+
+// HasChanges checks if the two sets of environment variables are different
+func (d EnvDiff) HasChanges() bool {
+	return len(d.OnlyInLeft) > 0 || len(d.OnlyInRight) > 0 || len(d.Changed) > 0
+}
+
+// DiffEnvs compares two sets of environment variables by the hashes of their values
+func DiffEnvs(left *ini.File, right *ini.File) EnvDiff {
+	var envDiff EnvDiff
+
+	for _, key := range left.Section("").Keys() {
+		if !right.Section("").HasKey(key.Name()) {
+			envDiff.OnlyInLeft = append(envDiff.OnlyInLeft, key.Name())
+		} else if HashEnvValue(key.Value()) != HashEnvValue(right.Section("").Key(key.Name()).Value()) {
+			envDiff.Changed = append(envDiff.Changed, key.Name())
+		} else {
+			envDiff.Identical = append(envDiff.Identical, key.Name())
+		}
+	}
+
+	for _, key := range right.Section("").Keys() {
+		if !left.Section("").HasKey(key.Name()) {
+			envDiff.OnlyInRight = append(envDiff.OnlyInRight, key.Name())
+		}
+	}
+
+	return envDiff
+}
+
+// DiffEnvKeys compares only the keys of two sets of environment variables, for values that can't be read. The keys
+// in both sets are not comparable, so they are neither changed nor identical.
+func DiffEnvKeys(left *ini.File, right *ini.File) EnvDiff {
+	var envDiff EnvDiff
+
+	for _, key := range left.Section("").Keys() {
+		if right.Section("").HasKey(key.Name()) {
+			envDiff.NotComparable = append(envDiff.NotComparable, key.Name())
+		} else {
+			envDiff.OnlyInLeft = append(envDiff.OnlyInLeft, key.Name())
+		}
+	}
+
+	for _, key := range right.Section("").Keys() {
+		if !left.Section("").HasKey(key.Name()) {
+			envDiff.OnlyInRight = append(envDiff.OnlyInRight, key.Name())
+		}
+	}
+
+	return envDiff
+}
+
+// DiffK8sDrift compares the environment variables stored for a target with the ones in its Kubernetes ConfigMap or
+// Secret. Only the keys of DigitalOcean secrets are compared, since only their encrypted values can be read.
+func DiffK8sDrift(provider Provider, target Target, envFile *ini.File, k8sEnvFile *ini.File) EnvDiff {
+	if HasEncryptedValues(provider, target.EnvType) {
+		return DiffEnvKeys(envFile, k8sEnvFile)
+	}
+
+	return DiffEnvs(envFile, k8sEnvFile)
+}
+
+// HasEncryptedValues checks if a provider only returns the encrypted values of a type, as DigitalOcean does for
+// secrets, so they can only be compared by their keys
+func HasEncryptedValues(provider Provider, envType string) bool {
+	return envType == "secrets" && provider.Name() == "DGO"
+}
+
+// HashEnvValue returns the hex encoded SHA-256 hash of a value
+func HashEnvValue(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
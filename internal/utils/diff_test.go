@@ -0,0 +1,145 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffEnvs(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     map[string]string
+		right    map[string]string
+		keysOnly bool
+		want     EnvDiff
+	}{
+		{
+			name: "identical",
+			left: map[string]string{"A": "1"}, right: map[string]string{"A": "1"},
+			want: EnvDiff{Identical: []string{"A"}},
+		},
+		{
+			name: "changed",
+			left: map[string]string{"A": "1"}, right: map[string]string{"A": "2"},
+			want: EnvDiff{Changed: []string{"A"}},
+		},
+		{
+			name: "only in one side",
+			left: map[string]string{"A": "1", "B": "2"}, right: map[string]string{"B": "2", "C": "3"},
+			want: EnvDiff{OnlyInLeft: []string{"A"}, OnlyInRight: []string{"C"}, Identical: []string{"B"}},
+		},
+		{
+			name: "empty value",
+			left: map[string]string{"A": ""}, right: map[string]string{"A": "x"},
+			want: EnvDiff{Changed: []string{"A"}},
+		},
+		{
+			name: "keys only",
+			left: map[string]string{"A": "EV[1:a]", "B": "EV[1:b]", "C": "EV[1:c]"}, right: map[string]string{"B": "EV[1:b]", "C": "EV[1:x]", "D": "EV[1:d]"},
+			keysOnly: true,
+			want:     EnvDiff{OnlyInLeft: []string{"A"}, OnlyInRight: []string{"D"}, NotComparable: []string{"B", "C"}},
+		},
+		{
+			name: "keys only without differences",
+			left: map[string]string{"A": "EV[1:a]"}, right: map[string]string{"A": "EV[1:x]"},
+			keysOnly: true,
+			want:     EnvDiff{NotComparable: []string{"A"}},
+		},
+		{
+			name: "empty",
+			left: map[string]string{}, right: map[string]string{},
+			want: EnvDiff{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffFunc := DiffEnvs
+			if tt.keysOnly {
+				diffFunc = DiffEnvKeys
+			}
+			got := diffFunc(newTestEnvFile(t, tt.left), newTestEnvFile(t, tt.right))
+
+			for _, field := range []struct {
+				name      string
+				got, want []string
+			}{
+				{"OnlyInLeft", got.OnlyInLeft, tt.want.OnlyInLeft},
+				{"OnlyInRight", got.OnlyInRight, tt.want.OnlyInRight},
+				{"Changed", got.Changed, tt.want.Changed},
+				{"Identical", got.Identical, tt.want.Identical},
+				{"NotComparable", got.NotComparable, tt.want.NotComparable},
+			} {
+				slices.Sort(field.got)
+				if !slices.Equal(field.got, field.want) {
+					t.Errorf("%s = %v, want %v", field.name, field.got, field.want)
+				}
+			}
+
+			if got.HasChanges() != tt.want.HasChanges() {
+				t.Errorf("HasChanges = %v, want %v", got.HasChanges(), tt.want.HasChanges())
+			}
+		})
+	}
+}
+
+func TestDiffK8sDrift(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerName string
+		envType      string
+		envs         map[string]string
+		k8sEnvs      map[string]string
+		wantDrift    bool
+	}{
+		{
+			name: "in sync", providerName: "FILE", envType: "envs",
+			envs: map[string]string{"A": "1"}, k8sEnvs: map[string]string{"A": "1"},
+		},
+		{
+			name: "missing in cluster", providerName: "FILE", envType: "envs",
+			envs: map[string]string{"A": "1", "B": "2"}, k8sEnvs: map[string]string{"A": "1"},
+			wantDrift: true,
+		},
+		{
+			name: "extra in cluster", providerName: "OCI", envType: "secrets",
+			envs: map[string]string{"A": "1"}, k8sEnvs: map[string]string{"A": "1", "B": "2"},
+			wantDrift: true,
+		},
+		{
+			name: "different value", providerName: "FILE", envType: "secrets",
+			envs: map[string]string{"A": "1"}, k8sEnvs: map[string]string{"A": "2"},
+			wantDrift: true,
+		},
+		{
+			name: "encrypted DigitalOcean secrets", providerName: "DGO", envType: "secrets",
+			envs: map[string]string{"A": "EV[1:abc]"}, k8sEnvs: map[string]string{"A": "plain"},
+		},
+		{
+			name: "missing DigitalOcean secret", providerName: "DGO", envType: "secrets",
+			envs: map[string]string{"A": "EV[1:abc]", "B": "EV[1:def]"}, k8sEnvs: map[string]string{"A": "plain"},
+			wantDrift: true,
+		},
+		{
+			name: "DigitalOcean envs", providerName: "DGO", envType: "envs",
+			envs: map[string]string{"A": "1"}, k8sEnvs: map[string]string{"A": "2"},
+			wantDrift: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &namedProvider{name: tt.providerName}
+			target := Target{Project: "p1", Environment: "dev", EnvType: tt.envType}
+
+			got := DiffK8sDrift(provider, target, newTestEnvFile(t, tt.envs), newTestEnvFile(t, tt.k8sEnvs))
+			if got.HasChanges() != tt.wantDrift {
+				t.Errorf("DiffK8sDrift() = %+v, want drift %v", got, tt.wantDrift)
+			}
+		})
+	}
+}
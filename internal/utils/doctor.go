@@ -0,0 +1,253 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// ConfigProblem is a problem found in the config file, located by its line (0 when the section is missing)
+type ConfigProblem struct {
+	Line    int
+	Message string
+}
+
+// providerConfigSections are the config section and keys each provider needs. The providers not listed either
+// have no section or have defaults for all of their keys.
+var providerConfigSections = map[string]struct {
+	section string
+	keys    []string
+}{
+	"AWS": {"AWS", []string{"aws_access_key_id", "aws_secret_access_key", "region"}},
+	"DGO": {"DGO", []string{"dgo_api_token"}},
+	"OCI": {"OCI", []string{"region", "key_file", "namespace", "user", "fingerprint", "tenancy", "bucket_name"}},
+	"SSM": {"AWS", []string{"aws_access_key_id", "aws_secret_access_key", "region"}},
+}
+
+// k8sConfigKeys are the keys an environment needs to mirror its variables to Kubernetes, and the keys of the
+// [K8S] section needed to connect to the cluster
+var (
+	k8sConfigKeys        = []string{"namespace", "configmap_name", "secret_name"}
+	k8sSectionConfigKeys = []string{"k8s_host", "k8s_token", "k8s_certificate_path"}
+)
+
+// ValidateConfig checks the structure of the config file content: the projects and their sections, the
+// provider of each environment and the properties it needs, the Kubernetes properties and the config
+// sections of the providers in use
+func ValidateConfig(content []byte) ([]ConfigProblem, error) {
+	cfg, err := ini.Load(content)
+	if err != nil {
+		// The parser only reports the text of a line without a key-value delimiter, so its number is looked up
+		var delimiterErr ini.ErrDelimiterNotFound
+		if errors.As(err, &delimiterErr) {
+			if line := findConfigLine(content, delimiterErr.Line); line > 0 {
+				return nil, fmt.Errorf("error parsing config file: line %d: key-value delimiter not found: %s", line, strings.TrimSpace(delimiterErr.Line))
+			}
+		}
+		return nil, fmt.Errorf("error parsing config file: %w", err)
+	}
+
+	lines := newConfigLines(content)
+	var problems []ConfigProblem
+	addProblem := func(line int, format string, v ...any) {
+		problems = append(problems, ConfigProblem{Line: line, Message: fmt.Sprintf(format, v...)})
+	}
+
+	if !cfg.Section("PROJECTS").HasKey("projects") {
+		addProblem(lines.find("PROJECTS", ""), "missing \"projects\" in section [PROJECTS]")
+	}
+
+	if cfg.Section("DGO.APP_COMPONENTS").HasKey("app_components") {
+		addProblem(lines.find("DGO.APP_COMPONENTS", "app_components"), "section [DGO.APP_COMPONENTS] is no longer supported. Configure an environment for each component with \"<environment>.app_component_name\" and remove the section")
+	}
+
+	var usedProviders []string
+	isK8sUsed := false
+
+	for _, project := range splitConfigList(cfg.Section("PROJECTS").Key("projects").Value()) {
+		sectionName := "\"" + project + "\""
+		section, err := cfg.GetSection(sectionName)
+		if err != nil {
+			sectionName = project
+			section, err = cfg.GetSection(sectionName)
+		}
+		if err != nil {
+			addProblem(lines.find("PROJECTS", "projects"), "project \"%s\" has no [\"%s\"] section", project, project)
+			continue
+		}
+
+		if !section.HasKey("environments") {
+			addProblem(lines.find(sectionName, ""), "project \"%s\" has no \"environments\"", project)
+			continue
+		}
+
+		for _, projEnv := range splitConfigList(section.Key("environments").Value()) {
+			property := func(name string) string {
+				return section.Key(projEnv + "." + name).Value()
+			}
+
+			providerName := property("provider")
+			switch {
+			case providerName == "":
+				addProblem(lines.find(sectionName, "environments"), "environment \"%s\" of project \"%s\" has no \"%s.provider\"", projEnv, project, projEnv)
+			case !slices.Contains(RegisteredProviders(), providerName):
+				addProblem(lines.find(sectionName, projEnv+".provider"), "invalid provider \"%s\" for environment \"%s\" of project \"%s\". Options are: %v", providerName, projEnv, project, RegisteredProviders())
+			default:
+				usedProviders = append(usedProviders, providerName)
+			}
+
+			isAppScope := property("scope") == "app"
+			switch providerName {
+			case "DGO":
+				if property("app_name") == "" {
+					addProblem(lines.find(sectionName, projEnv+".provider"), "DigitalOcean environment \"%s\" of project \"%s\" has no \"%s.app_name\"", projEnv, project, projEnv)
+				}
+				if !isAppScope && !section.HasKey(projEnv+".app_component_name") {
+					addProblem(lines.find(sectionName, projEnv+".provider"), "DigitalOcean environment \"%s\" of project \"%s\" has no \"%s.app_component_name\"", projEnv, project, projEnv)
+				} else if !isAppScope && property("app_component_name") == "" {
+					addProblem(lines.find(sectionName, projEnv+".app_component_name"), "DigitalOcean environment \"%s\" of project \"%s\" has an empty \"%s.app_component_name\". Use \"%s.scope = app\" for the app-level variables", projEnv, project, projEnv, projEnv)
+				}
+			case "AWS":
+				if !isAppScope && property("branch_name") == "" {
+					addProblem(lines.find(sectionName, projEnv+".provider"), "AWS Amplify environment \"%s\" of project \"%s\" has no \"%s.branch_name\"", projEnv, project, projEnv)
+				}
+			}
+
+			var setK8sKeys, missingK8sKeys []string
+			for _, key := range k8sConfigKeys {
+				if property(key) == "" {
+					missingK8sKeys = append(missingK8sKeys, projEnv+"."+key)
+				} else {
+					setK8sKeys = append(setK8sKeys, projEnv+"."+key)
+				}
+			}
+			if len(setK8sKeys) > 0 && len(missingK8sKeys) > 0 {
+				addProblem(lines.find(sectionName, setK8sKeys[0]), "environment \"%s\" of project \"%s\" has Kubernetes settings but no %s", projEnv, project, strings.Join(missingK8sKeys, ", "))
+			}
+			isK8sUsed = isK8sUsed || len(setK8sKeys) > 0
+		}
+	}
+
+	var usedSections []string
+	for _, providerName := range usedProviders {
+		if configSection, ok := providerConfigSections[providerName]; ok && !slices.Contains(usedSections, configSection.section) {
+			usedSections = append(usedSections, configSection.section)
+			problems = append(problems, validateConfigSection(cfg, lines, configSection.section, configSection.keys)...)
+		}
+	}
+	if isK8sUsed {
+		problems = append(problems, validateConfigSection(cfg, lines, "K8S", k8sSectionConfigKeys)...)
+	}
+
+	return problems, nil
+}
+
+// validateConfigSection checks that a config section has a value for each key
+func validateConfigSection(cfg *ini.File, lines configLines, sectionName string, keys []string) []ConfigProblem {
+	section, err := cfg.GetSection(sectionName)
+	if err != nil {
+		return []ConfigProblem{{Message: fmt.Sprintf("missing section [%s], used by the configured environments", sectionName)}}
+	}
+
+	var problems []ConfigProblem
+	for _, key := range keys {
+		if section.Key(key).Value() == "" {
+			problems = append(problems, ConfigProblem{Line: lines.find(sectionName, ""), Message: fmt.Sprintf("missing \"%s\" in section [%s]", key, sectionName)})
+		}
+	}
+
+	return problems
+}
+
+// splitConfigList splits a comma separated config value, ignoring spaces and empty items
+func splitConfigList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(strings.ReplaceAll(value, " ", ""), ",") {
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// configLine is the section and key of a line of the config file, to locate problems
+type configLine struct {
+	section string
+	key     string
+}
+
+// configLines holds the lines of a config file
+type configLines []configLine
+
+// newConfigLines reads the section and key of each line of a config file
+// findConfigLine returns the number of the first line of the config file content with the given text, ignoring the
+// surrounding whitespace, or 0 if there is none
+func findConfigLine(content []byte, text string) int {
+	text = strings.TrimSpace(text)
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		lineNumber++
+		if strings.TrimSpace(scanner.Text()) == text {
+			return lineNumber
+		}
+	}
+
+	return 0
+}
+
+func newConfigLines(content []byte) configLines {
+	var lines configLines
+	section := ini.DefaultSection
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key := ""
+
+		switch {
+		case strings.HasPrefix(line, "["):
+			if end := strings.Index(line, "]"); end > 0 {
+				section = strings.TrimSpace(line[1:end])
+			}
+		case line != "" && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, ";"):
+			if end := strings.IndexAny(line, "=:"); end > 0 {
+				key = strings.TrimSpace(line[:end])
+			}
+		}
+
+		lines = append(lines, configLine{section: section, key: key})
+	}
+
+	return lines
+}
+
+// find returns the line of a key, or of the section header when key is empty or not found. It returns 0 when
+// the section is not found.
+func (lines configLines) find(section string, key string) int {
+	sectionLine := 0
+	for i, line := range lines {
+		if line.section != section {
+			continue
+		}
+		if sectionLine == 0 {
+			sectionLine = i + 1
+		}
+		if key != "" && line.key == key {
+			return i + 1
+		}
+	}
+
+	return sectionLine
+}
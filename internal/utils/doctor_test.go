@@ -0,0 +1,191 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    []ConfigProblem
+		wantErr string
+	}{
+		{
+			name: "valid",
+			config: `[PROJECTS]
+projects = front,back
+
+[FILE]
+path = /tmp/envs
+
+["front"]
+environments = dev,prod
+dev.provider = FILE
+prod.provider = FILE
+prod.namespace = apps
+prod.configmap_name = front-config
+prod.secret_name = front-secret
+
+["back"]
+environments = dev
+dev.provider = FILE
+
+[K8S]
+k8s_host = https://cluster
+k8s_token = token
+k8s_certificate_path = /tmp/ca.crt
+`,
+		},
+		{
+			name:   "missing projects",
+			config: "[PROJECTS]\n",
+			want:   []ConfigProblem{{Line: 1, Message: `missing "projects" in section [PROJECTS]`}},
+		},
+		{
+			name: "missing project section and environments",
+			config: `[PROJECTS]
+projects = front, back
+
+["back"]
+dev.provider = FILE
+`,
+			want: []ConfigProblem{
+				{Line: 2, Message: `project "front" has no ["front"] section`},
+				{Line: 4, Message: `project "back" has no "environments"`},
+			},
+		},
+		{
+			name: "missing and invalid providers",
+			config: `[PROJECTS]
+projects = front
+
+["front"]
+environments = dev,prod
+prod.provider = GCP
+`,
+			want: []ConfigProblem{
+				{Line: 5, Message: `environment "dev" of project "front" has no "dev.provider"`},
+				{Line: 6, Message: `invalid provider "GCP" for environment "prod" of project "front". Options are: ` + fmt.Sprint(RegisteredProviders())},
+			},
+		},
+		{
+			name: "provider properties",
+			config: `[PROJECTS]
+projects = front
+
+["front"]
+environments = dev,prod,app,web,api
+dev.provider = DGO
+dev.app_component_name = web
+prod.provider = AWS
+app.provider = DGO
+app.app_name = front-app
+app.scope = app
+web.provider = DGO
+web.app_name = front-app
+api.provider = DGO
+api.app_name = front-app
+api.app_component_name =
+
+[AWS]
+aws_access_key_id = id
+aws_secret_access_key = secret
+region = us-east-1
+
+[DGO]
+dgo_api_token = token
+`,
+			want: []ConfigProblem{
+				{Line: 6, Message: `DigitalOcean environment "dev" of project "front" has no "dev.app_name"`},
+				{Line: 8, Message: `AWS Amplify environment "prod" of project "front" has no "prod.branch_name"`},
+				{Line: 12, Message: `DigitalOcean environment "web" of project "front" has no "web.app_component_name"`},
+				{Line: 16, Message: `DigitalOcean environment "api" of project "front" has an empty "api.app_component_name". Use "api.scope = app" for the app-level variables`},
+			},
+		},
+		{
+			name: "incomplete kubernetes settings",
+			config: `[PROJECTS]
+projects = back
+
+["back"]
+environments = prod
+prod.provider = FILE
+prod.secret_name = back-secret
+`,
+			want: []ConfigProblem{
+				{Line: 7, Message: `environment "prod" of project "back" has Kubernetes settings but no prod.namespace, prod.configmap_name`},
+				{Line: 0, Message: `missing section [K8S], used by the configured environments`},
+			},
+		},
+		{
+			name: "provider sections",
+			config: `[PROJECTS]
+projects = back
+
+["back"]
+environments = dev,prod
+dev.provider = OCI
+prod.provider = SSM
+
+[AWS]
+aws_access_key_id = id
+region = us-east-1
+`,
+			want: []ConfigProblem{
+				{Line: 0, Message: `missing section [OCI], used by the configured environments`},
+				{Line: 9, Message: `missing "aws_secret_access_key" in section [AWS]`},
+			},
+		},
+		{
+			name: "app components section of earlier versions",
+			config: `[PROJECTS]
+projects = front
+
+["front"]
+environments = dev
+dev.provider = DGO
+dev.app_name = front-app
+dev.app_component_name = web
+
+[DGO]
+dgo_api_token = token
+
+[DGO.APP_COMPONENTS]
+app_components = web,admin
+`,
+			want: []ConfigProblem{
+				{Line: 14, Message: `section [DGO.APP_COMPONENTS] is no longer supported. Configure an environment for each component with "<environment>.app_component_name" and remove the section`},
+			},
+		},
+		{
+			name:    "invalid ini",
+			config:  "[PROJECTS\n",
+			wantErr: "unclosed section",
+		},
+		{
+			name:    "line without delimiter",
+			config:  "[PROJECTS]\nprojects = back-end\nbroken line\n",
+			wantErr: "line 3: key-value delimiter not found: broken line",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateConfig([]byte(tt.config))
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
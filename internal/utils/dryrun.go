@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	"gopkg.in/ini.v1"
+)
+
+// DryRun is set by the --dry-run flag. The providers and the Kubernetes helpers then print the changes they
+// would make instead of making them.
+var DryRun bool
+
+// dryRunProvider is a Provider that prints the changes of Save instead of saving them
+type dryRunProvider struct {
+	Provider
+}
+
+// dryRunLayeredProvider is a dryRunProvider for providers with inherited levels
+type dryRunLayeredProvider struct {
+	LayeredProvider
+}
+
+// dryRunVersionedProvider is a dryRunProvider for providers that keep the previous versions of a target
+type dryRunVersionedProvider struct {
+	VersionedProvider
+}
+
+// layeredVersionedProvider is implemented by providers with inherited levels that keep the previous versions
+type layeredVersionedProvider interface {
+	LayeredProvider
+	VersionedProvider
+}
+
+// dryRunLayeredVersionedProvider is a dryRunProvider for providers with inherited levels that keep the previous
+// versions of a target
+type dryRunLayeredVersionedProvider struct {
+	layeredVersionedProvider
+}
+
+// newDryRunProvider wraps a provider so it doesn't save anything, keeping the optional interfaces it implements
+func newDryRunProvider(provider Provider) Provider {
+	switch provider := provider.(type) {
+	case layeredVersionedProvider:
+		return &dryRunLayeredVersionedProvider{layeredVersionedProvider: provider}
+	case LayeredProvider:
+		return &dryRunLayeredProvider{LayeredProvider: provider}
+	case VersionedProvider:
+		return &dryRunVersionedProvider{VersionedProvider: provider}
+	default:
+		return &dryRunProvider{Provider: provider}
+	}
+}
+
+// Save prints the keys that would be created, updated and deleted in the target
+func (p *dryRunProvider) Save(target Target, envFile *ini.File) error {
+	return printDryRunSave(p.Provider, target, envFile)
+}
+
+// Save prints the keys that would be created, updated and deleted in the target
+func (p *dryRunLayeredProvider) Save(target Target, envFile *ini.File) error {
+	return printDryRunSave(p.LayeredProvider, target, envFile)
+}
+
+// Save prints the keys that would be created, updated and deleted in the target
+func (p *dryRunVersionedProvider) Save(target Target, envFile *ini.File) error {
+	return printDryRunSave(p.VersionedProvider, target, envFile)
+}
+
+// Save prints the keys that would be created, updated and deleted in the target
+func (p *dryRunLayeredVersionedProvider) Save(target Target, envFile *ini.File) error {
+	return printDryRunSave(p.layeredVersionedProvider, target, envFile)
+}
+
+// SeparatesTypes returns whether the wrapped provider stores the envs and secrets of a target apart
+func (p *dryRunProvider) SeparatesTypes(target Target) bool {
+	return SeparatesTypes(p.Provider, target)
+}
+
+// SeparatesTypes returns whether the wrapped provider stores the envs and secrets of a target apart
+func (p *dryRunLayeredProvider) SeparatesTypes(target Target) bool {
+	return SeparatesTypes(p.LayeredProvider, target)
+}
+
+// SeparatesTypes returns whether the wrapped provider stores the envs and secrets of a target apart
+func (p *dryRunVersionedProvider) SeparatesTypes(target Target) bool {
+	return SeparatesTypes(p.VersionedProvider, target)
+}
+
+// SeparatesTypes returns whether the wrapped provider stores the envs and secrets of a target apart
+func (p *dryRunLayeredVersionedProvider) SeparatesTypes(target Target) bool {
+	return SeparatesTypes(p.layeredVersionedProvider, target)
+}
+
+// printDryRunSave compares the key-value set that would be saved with the stored one and prints the changes. A
+// key-value set the provider would refuse returns the same error as Save.
+func printDryRunSave(provider Provider, target Target, envFile *ini.File) error {
+	if validatingProvider, ok := provider.(ValidatingProvider); ok {
+		err := validatingProvider.Validate(target, envFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	currentEnvFile, err := provider.Load(target)
+	if err != nil {
+		return err
+	}
+
+	printDryRunChanges(fmt.Sprintf("%s of project \"%s\" in \"%s\" environment (%s)", target.EnvType, target.Project, target.Environment, provider.Describe(target)), DiffEnvs(currentEnvFile, envFile))
+	return nil
+}
+
+// printDryRunK8sChanges prints the keys that would change in the ConfigMap or Secret of a target. With isMerge,
+// envFile only has the keys to create or update, and the other keys are kept.
+func printDryRunK8sChanges(target Target, envFile *ini.File, deletedEnvs []string, isMerge bool) error {
+	currentEnvFile, err := LoadK8sTarget(target)
+	if err != nil {
+		return err
+	}
+
+	newEnvFile := envFile
+	if isMerge {
+		newEnvFile = ini.Empty()
+		for _, key := range currentEnvFile.Section("").Keys() {
+			newEnvFile.Section("").Key(key.Name()).SetValue(key.Value())
+		}
+		for _, key := range envFile.Section("").Keys() {
+			newEnvFile.Section("").Key(key.Name()).SetValue(key.Value())
+		}
+		for _, envName := range deletedEnvs {
+			newEnvFile.Section("").DeleteKey(envName)
+		}
+	}
+
+	printDryRunChanges(DescribeK8sTarget(target), DiffEnvs(currentEnvFile, newEnvFile))
+	return nil
+}
+
+// printDryRunChanges prints the keys of a diff as changes that are not saved
+func printDryRunChanges(description string, envDiff EnvDiff) {
+	if !envDiff.HasChanges() {
+		fmt.Printf("[DRY RUN] No changes in %s\n", description)
+		return
+	}
+
+	fmt.Printf("[DRY RUN] Changes not saved in %s:\n", description)
+	for _, envName := range envDiff.OnlyInRight {
+		fmt.Printf("  + %s (created)\n", envName)
+	}
+	for _, envName := range envDiff.Changed {
+		fmt.Printf("  ~ %s (updated)\n", envName)
+	}
+	for _, envName := range envDiff.OnlyInLeft {
+		fmt.Printf("  - %s (deleted)\n", envName)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDryRunProviderSave(t *testing.T) {
+	userHome := setTestConfig(t, "")
+	target := Target{Project: "p1", Environment: "dev", EnvType: "envs"}
+
+	provider, err := GetProviderByName("FILE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = provider.Save(target, newTestEnvFile(t, map[string]string{"A": "1", "B": "2"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	DryRun = true
+	t.Cleanup(func() { DryRun = false })
+
+	dryRunProvider, err := GetProviderByName("FILE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dryRunProvider.Name() != "FILE" {
+		t.Errorf("Name() = %q, want %q", dryRunProvider.Name(), "FILE")
+	}
+	if got, want := dryRunProvider.Describe(target), provider.Describe(target); got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+
+	err = dryRunProvider.Save(target, newTestEnvFile(t, map[string]string{"A": "changed", "C": "3"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envFile, err := provider.Load(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := envFile.Section("").KeysHash(), map[string]string{"A": "1", "B": "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stored envs after a dry-run Save = %v, want %v", got, want)
+	}
+
+	// A dry-run Save of a target that doesn't exist yet doesn't create it
+	newTarget := Target{Project: "p2", Environment: "dev", EnvType: "envs"}
+	err = dryRunProvider.Save(newTarget, newTestEnvFile(t, map[string]string{"A": "1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(userHome, ".env-manager/files/p2/*")); len(matches) > 0 {
+		t.Errorf("dry-run Save created %v", matches)
+	}
+}
+
+func TestDryRunProviderValidates(t *testing.T) {
+	setTestConfig(t, "[PROJECTS]\nprojects = p1\n[\"p1\"]\nenvironments = dev\ndev.provider = SSM\n")
+	target := Target{Project: "p1", Environment: "dev", EnvType: "envs"}
+
+	tests := []struct {
+		name    string
+		envs    map[string]string
+		wantErr string
+	}{
+		{name: "valid", envs: map[string]string{"A": "changed", "B": "2"}},
+		{name: "empty value", envs: map[string]string{"A": ""}, wantErr: "has an empty value"},
+		{name: "other type", envs: map[string]string{"TOKEN": "t"}, wantErr: "already exists as SecureString"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeSSM{parameters: map[string][2]string{"/p1/dev/A": {"String", "1"}, "/p1/dev/TOKEN": {"SecureString", "t"}}}
+			provider := newDryRunProvider(newTestSSMProvider(t, fake))
+
+			err := provider.Save(target, newTestEnvFile(t, tt.envs))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Save() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if len(fake.putNames) > 0 || len(fake.deleteBatches) > 0 {
+				t.Errorf("dry-run Save() wrote parameters: put %v, deleted %v", fake.putNames, fake.deleteBatches)
+			}
+		})
+	}
+}
+
+// layeredVersionedFileProvider is a versionedFileProvider with inherited levels
+type layeredVersionedFileProvider struct {
+	versionedFileProvider
+}
+
+func (p *layeredVersionedFileProvider) LoadLayers(target Target) ([]EnvLayer, error) {
+	return nil, nil
+}
+
+func TestNewDryRunProviderKeepsInterfaces(t *testing.T) {
+	tests := []struct {
+		name          string
+		provider      Provider
+		wantLayered   bool
+		wantVersioned bool
+	}{
+		{name: "plain", provider: &FileProvider{}},
+		{name: "layered", provider: &DGOProvider{}, wantLayered: true},
+		{name: "versioned", provider: &OCIProvider{}, wantVersioned: true},
+		{name: "layered and versioned", provider: &layeredVersionedFileProvider{}, wantLayered: true, wantVersioned: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newDryRunProvider(tt.provider)
+
+			if _, ok := provider.(LayeredProvider); ok != tt.wantLayered {
+				t.Errorf("implements LayeredProvider = %v, want %v", ok, tt.wantLayered)
+			}
+			if _, ok := provider.(VersionedProvider); ok != tt.wantVersioned {
+				t.Errorf("implements VersionedProvider = %v, want %v", ok, tt.wantVersioned)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/ini.v1"
+)
+
+// EditDotenv calls edit on a dotenv file until it can be parsed, accepting the keys already in envFile even if they
+// aren't valid dotenv keys. After an invalid edit, retry is called with the parse error and, if it returns false,
+// nil is returned.
+func EditDotenv(filePath string, envFile *ini.File, edit func(filePath string) error, retry func(err error) bool) (*ini.File, error) {
+	knownKeys := envFile.Section("").KeyStrings()
+
+	for {
+		err := edit(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		editedContent, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading temporary file: %w", err)
+		}
+
+		editedEnvFile, err := ParseDotenvWithKeys(editedContent, knownKeys)
+		if err == nil {
+			return editedEnvFile, nil
+		}
+
+		if !retry(err) {
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditDotenv(t *testing.T) {
+	envFile := newTestEnvFile(t, map[string]string{"HOST": "h", "my key": "v"})
+
+	tests := []struct {
+		name        string
+		edits       []string
+		retry       bool
+		editErr     error
+		want        map[string]string
+		wantEdits   int
+		wantRetries int
+		wantErr     bool
+	}{
+		{
+			name:      "valid edit",
+			edits:     []string{"HOST=changed\nNEW=1\n"},
+			want:      map[string]string{"HOST": "changed", "NEW": "1"},
+			wantEdits: 1,
+		},
+		{
+			name:      "stored keys that aren't dotenv keys are accepted",
+			edits:     []string{"HOST=h\nmy key=changed\n"},
+			want:      map[string]string{"HOST": "h", "my key": "changed"},
+			wantEdits: 1,
+		},
+		{
+			name:        "new keys that aren't dotenv keys are refused",
+			edits:       []string{"other key=1\n"},
+			wantEdits:   1,
+			wantRetries: 1,
+		},
+		{
+			name:        "invalid edit is edited again",
+			edits:       []string{"HOST\n", "HOST='unclosed\n", "HOST=fixed\n"},
+			retry:       true,
+			want:        map[string]string{"HOST": "fixed"},
+			wantEdits:   3,
+			wantRetries: 2,
+		},
+		{
+			name:      "editor error",
+			edits:     []string{"HOST=h\n"},
+			editErr:   errors.New("editor failed"),
+			wantEdits: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "edit.env")
+			edits, retries := 0, 0
+
+			edit := func(filePath string) error {
+				edits++
+				if tt.editErr != nil {
+					return tt.editErr
+				}
+				return os.WriteFile(filePath, []byte(tt.edits[edits-1]), 0600)
+			}
+			retry := func(err error) bool {
+				retries++
+				return tt.retry
+			}
+
+			got, err := EditDotenv(filePath, envFile, edit, retry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EditDotenv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if edits != tt.wantEdits || retries != tt.wantRetries {
+				t.Errorf("EditDotenv() edited %d times and asked to retry %d times, want %d and %d", edits, retries, tt.wantEdits, tt.wantRetries)
+			}
+
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("EditDotenv() = %v, want nil", got.Section("").KeysHash())
+				}
+				return
+			}
+			if got == nil || !maps.Equal(got.Section("").KeysHash(), tt.want) {
+				t.Fatalf("EditDotenv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	"sigs.k8s.io/yaml"
+)
+
+var ValidExportFormats = []string{"dotenv", "shell", "json", "yaml", "docker", "k8s"}
+
+// plainDotenvValue matches the values that can be written in a dotenv file without quotes
+var plainDotenvValue = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,-]*$`)
+
+// shellVariableName matches the names that can be used as POSIX shell variables
+var shellVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// FormatEnvs writes the environment variables of a target in one of the ValidExportFormats, sorted by key
+func FormatEnvs(envFile *ini.File, target Target, format string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	keys := envFile.Section("").Keys()
+	slices.SortFunc(keys, func(a, b *ini.Key) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	switch format {
+	case "dotenv":
+		for _, key := range keys {
+			fmt.Fprintf(&buffer, "%s=%s\n", key.Name(), QuoteDotenvValue(key.Value()))
+		}
+
+	case "shell":
+		for _, key := range keys {
+			if !shellVariableName.MatchString(key.Name()) {
+				return nil, fmt.Errorf("\"%s\" is not a valid shell variable name", key.Name())
+			}
+			fmt.Fprintf(&buffer, "export %s=%s\n", key.Name(), QuoteShellValue(key.Value()))
+		}
+
+	case "docker":
+		// Docker env files don't support quotes or multiline values, everything after "=" is the value
+		for _, key := range keys {
+			if strings.ContainsAny(key.Value(), "\r\n") {
+				return nil, fmt.Errorf("value of \"%s\" has multiple lines, which Docker env files don't support", key.Name())
+			}
+			fmt.Fprintf(&buffer, "%s=%s\n", key.Name(), key.Value())
+		}
+
+	case "json":
+		jsonEnvs, err := json.MarshalIndent(envFile.Section("").KeysHash(), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("error encoding JSON: %w", err)
+		}
+		buffer.Write(jsonEnvs)
+		buffer.WriteString("\n")
+
+	case "yaml":
+		yamlEnvs, err := yaml.Marshal(envFile.Section("").KeysHash())
+		if err != nil {
+			return nil, fmt.Errorf("error encoding YAML: %w", err)
+		}
+		buffer.Write(yamlEnvs)
+
+	case "k8s":
+		manifest, err := yaml.Marshal(getK8sManifest(envFile, target))
+		if err != nil {
+			return nil, fmt.Errorf("error encoding Kubernetes manifest: %w", err)
+		}
+		buffer.Write(manifest)
+
+	default:
+		return nil, fmt.Errorf("invalid format \"%s\". Options are: %v", format, ValidExportFormats)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// QuoteDotenvValue returns a value as written in a dotenv file: as is when it's safe, otherwise double quoted with
+// backslash escapes
+func QuoteDotenvValue(value string) string {
+	if plainDotenvValue.MatchString(value) {
+		return value
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "$", `\$`, "`", "\\`")
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// QuoteShellValue returns a value single quoted for POSIX shells
+func QuoteShellValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// getK8sManifest returns a ConfigMap (for envs) or an Opaque Secret (for secrets) manifest with the environment
+// variables of a target. The name and namespace come from the Kubernetes settings of the target, when set.
+func getK8sManifest(envFile *ini.File, target Target) map[string]any {
+	kind, nameProperty := "ConfigMap", ".configmap_name"
+	if target.EnvType == "secrets" {
+		kind, nameProperty = "Secret", ".secret_name"
+	}
+
+	metadata := map[string]any{
+		"name": GetConfigPropertyOrDefault(target.Project, target.Environment+nameProperty, target.Project+"-"+target.Environment),
+	}
+	if namespace := GetConfigPropertyOrDefault(target.Project, target.Environment+".namespace", ""); namespace != "" {
+		metadata["namespace"] = namespace
+	}
+
+	manifest := map[string]any{
+		"apiVersion": "v1",
+		"kind":       kind,
+		"metadata":   metadata,
+	}
+
+	if target.EnvType == "secrets" {
+		data := make(map[string]string)
+		for _, key := range envFile.Section("").Keys() {
+			data[key.Name()] = base64.StdEncoding.EncodeToString([]byte(key.Value()))
+		}
+		manifest["type"] = "Opaque"
+		manifest["data"] = data
+	} else {
+		manifest["data"] = envFile.Section("").KeysHash()
+	}
+
+	return manifest
+}
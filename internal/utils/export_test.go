@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestQuoteDotenvValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "postgres://db:5432/app", want: "postgres://db:5432/app"},
+		{name: "empty", value: "", want: ""},
+		{name: "space", value: "hello world", want: `"hello world"`},
+		{name: "double quote", value: `say "hi"`, want: `"say \"hi\""`},
+		{name: "multiline", value: "line1\nline2", want: `"line1\nline2"`},
+		{name: "expansion", value: "$HOME", want: `"\$HOME"`},
+		{name: "backslash", value: `a\b`, want: `"a\\b"`},
+		{name: "hash", value: "a#b", want: `"a#b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuoteDotenvValue(tt.value); got != tt.want {
+				t.Errorf("QuoteDotenvValue(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteShellValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "value", want: "'value'"},
+		{name: "empty", value: "", want: "''"},
+		{name: "single quote", value: "it's", want: `'it'\''s'`},
+		{name: "expansion", value: "$(rm -rf /)", want: "'$(rm -rf /)'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuoteShellValue(tt.value); got != tt.want {
+				t.Errorf("QuoteShellValue(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatEnvs(t *testing.T) {
+	setTestConfig(t, "[\"p1\"]\nenvironments = dev\ndev.provider = FILE\ndev.namespace = apps\ndev.secret_name = p1-secret\n")
+
+	tests := []struct {
+		name    string
+		envs    map[string]string
+		target  Target
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "dotenv",
+			envs:   map[string]string{"A": "1", "B": "two words"},
+			format: "dotenv",
+			want:   "A=1\nB=\"two words\"\n",
+		},
+		{
+			name:   "shell",
+			envs:   map[string]string{"A": "it's"},
+			format: "shell",
+			want:   "export A='it'\\''s'\n",
+		},
+		{
+			name:    "shell invalid name",
+			envs:    map[string]string{"MY-VAR": "1"},
+			format:  "shell",
+			wantErr: true,
+		},
+		{
+			name:   "docker",
+			envs:   map[string]string{"A": "two words"},
+			format: "docker",
+			want:   "A=two words\n",
+		},
+		{
+			name:    "docker multiline",
+			envs:    map[string]string{"A": "line1\nline2"},
+			format:  "docker",
+			wantErr: true,
+		},
+		{
+			name:   "json",
+			envs:   map[string]string{"A": "line1\nline2"},
+			format: "json",
+			want:   "{\n  \"A\": \"line1\\nline2\"\n}\n",
+		},
+		{
+			name:   "yaml",
+			envs:   map[string]string{"A": "1", "B": "x"},
+			format: "yaml",
+			want:   "A: \"1\"\nB: x\n",
+		},
+		{
+			name:   "k8s configmap",
+			envs:   map[string]string{"A": "1"},
+			target: Target{Project: "p1", Environment: "dev", EnvType: "envs"},
+			format: "k8s",
+			want:   "apiVersion: v1\ndata:\n  A: \"1\"\nkind: ConfigMap\nmetadata:\n  name: p1-dev\n  namespace: apps\n",
+		},
+		{
+			name:   "k8s secret",
+			envs:   map[string]string{"A": "secret"},
+			target: Target{Project: "p1", Environment: "dev", EnvType: "secrets"},
+			format: "k8s",
+			want:   "apiVersion: v1\ndata:\n  A: c2VjcmV0\nkind: Secret\nmetadata:\n  name: p1-secret\n  namespace: apps\ntype: Opaque\n",
+		},
+		{
+			name:    "invalid format",
+			envs:    map[string]string{"A": "1"},
+			format:  "toml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatEnvs(newTestEnvFile(t, tt.envs), tt.target, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatEnvs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FormatEnvs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,51 +5,98 @@ Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
 package utils
 
 import (
-	"log"
+	"fmt"
 	"slices"
 	"strings"
 
 	"gopkg.in/ini.v1"
 )
 
-func GetProjects() []string {
+func GetProjects() ([]string, error) {
 	configFileName := GetConfigFileName()
 
 	cfg, err := ini.Load(configFileName)
 	if err != nil {
-		log.Fatalf("Error loading config file: %v", err)
+		return nil, fmt.Errorf("error loading config file: %w", err)
 	}
 
 	projects := strings.Split(strings.ReplaceAll(cfg.Section("PROJECTS").Key("projects").Value(), " ", ""), ",")
-	return projects
+	return projects, nil
 }
 
-func GetEnvironments() []string {
+func GetEnvironments() ([]string, error) {
 	configFileName := GetConfigFileName()
 
 	cfg, err := ini.Load(configFileName)
 	if err != nil {
-		log.Fatalf("Error loading config file: %v", err)
+		return nil, fmt.Errorf("error loading config file: %w", err)
 	}
 
 	environemnts := strings.Split(strings.ReplaceAll(cfg.Section("ENVIRONMENTS").Key("environments").Value(), " ", ""), ",")
-	return environemnts
+	return environemnts, nil
 }
 
-func GetProjectProviders(projects []string, environments []string) []ProjectProvider {
+// GetProjectEnvironments returns the environments configured for a project
+func GetProjectEnvironments(project string) ([]string, error) {
+	environments, err := GetConfigProperty(project, "environments")
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strings.ReplaceAll(environments, " ", ""), ","), nil
+}
+
+// ValidateProjectEnvironment checks if a project is configured and has the given environment
+func ValidateProjectEnvironment(project string, projEnvironment string) error {
+	if !StringInSlice(project, ValidProjects) {
+		return fmt.Errorf("invalid project \"%s\". Options are: %v", project, ValidProjects)
+	}
+
+	projEnvironments, err := GetProjectEnvironments(project)
+	if err != nil {
+		return err
+	}
+
+	if !StringInSlice(projEnvironment, projEnvironments) {
+		return fmt.Errorf("invalid environment \"%s\" for project \"%s\". Options are: %v", projEnvironment, project, projEnvironments)
+	}
+
+	return nil
+}
+
+// GetAllEnvironments returns the environments that "-e all" applies to, which are the given project environments
+// without the ones with the app scope, since the other environments already inherit their variables
+func GetAllEnvironments(project string, environments []string) []string {
+	var allEnvironments []string
+	for _, environment := range environments {
+		if !IsAppScope(Target{Project: project, Environment: environment}) {
+			allEnvironments = append(allEnvironments, environment)
+		}
+	}
+
+	return allEnvironments
+}
+
+// GetProjectProviders returns the providers used by the environments of each project
+func GetProjectProviders(projects []string) ([]ProjectProvider, error) {
 	configFileName := GetConfigFileName()
 
 	cfg, err := ini.Load(configFileName)
 	if err != nil {
-		log.Fatalf("Error loading config file: %v", err)
+		return nil, fmt.Errorf("error loading config file: %w", err)
 	}
 
 	var projectProviders []ProjectProvider
 	for _, project := range projects {
+		section := cfg.Section("\"" + project + "\"")
+		environments := strings.Split(strings.ReplaceAll(section.Key("environments").Value(), " ", ""), ",")
+
 		var providers []string
 		for _, environment := range environments {
-			provider := cfg.Section("\"" + project + "\"").Key(environment + ".provider").Value()
-			providers = append(providers, provider)
+			provider := section.Key(environment + ".provider").Value()
+			if provider != "" {
+				providers = append(providers, provider)
+			}
 		}
 
 		slices.Sort(providers)
@@ -57,36 +104,96 @@ func GetProjectProviders(projects []string, environments []string) []ProjectProv
 
 		projectProviders = append(projectProviders, ProjectProvider{Name: project, CloudProvider: providers})
 	}
-	return projectProviders
+	return projectProviders, nil
 }
 
-func GetAppComponents() []string {
-	configFileName := GetConfigFileName()
+// ProjectEnvironmentConfig is the configuration of a project environment, with empty values for the unset properties
+type ProjectEnvironmentConfig struct {
+	Project          string
+	Environment      string
+	Provider         string
+	Scope            string
+	BranchName       string
+	AppName          string
+	AppComponentName string
+	Namespace        string
+	ConfigMapName    string
+	SecretName       string
+}
 
-	cfg, err := ini.Load(configFileName)
-	if err != nil {
-		log.Fatalf("Error loading config file: %v", err)
+// GetProjectEnvironmentConfig reads the configuration of a project environment
+func GetProjectEnvironmentConfig(project string, projEnvironment string) ProjectEnvironmentConfig {
+	property := func(name string) string {
+		return GetConfigPropertyOrDefault(project, projEnvironment+"."+name, "")
 	}
 
-	appComponents := strings.Split(strings.ReplaceAll(cfg.Section("DGO.APP_COMPONENTS").Key("app_components").Value(), " ", ""), ",")
-	return appComponents
+	return ProjectEnvironmentConfig{
+		Project:          project,
+		Environment:      projEnvironment,
+		Provider:         property("provider"),
+		Scope:            property("scope"),
+		BranchName:       property("branch_name"),
+		AppName:          property("app_name"),
+		AppComponentName: property("app_component_name"),
+		Namespace:        property("namespace"),
+		ConfigMapName:    property("configmap_name"),
+		SecretName:       property("secret_name"),
+	}
 }
 
-func GetBucketName() string {
+func GetBucketName() (string, error) {
 	configFileName := GetConfigFileName()
 
 	cfg, err := ini.Load(configFileName)
 	if err != nil {
-		log.Fatalf("Error loading config file: %v", err)
+		return "", fmt.Errorf("error loading config file: %w", err)
 	}
 
-	return cfg.Section("OCI").Key("bucket_name").Value()
+	return cfg.Section("OCI").Key("bucket_name").Value(), nil
 }
 
 var ValidTypes = []string{"envs", "secrets"}
+var ValidScopes = []string{"RUN_TIME", "BUILD_TIME", "RUN_AND_BUILD_TIME"}
+
+var ValidProjects []string
+var ValidEnvs []string
+var ProjectProviders []ProjectProvider
+var BucketName string
+
+// ConfigGlobalsErr is the error of loading the config globals at startup. The root command reports it before
+// running any command but doctor, which checks the config file itself.
+var ConfigGlobalsErr error
+
+// The config globals are left empty until the config file is created, so the configure command and the tests
+// can run without it. The root command checks for the config file before running any other command.
+func init() {
+	if !ConfigFileExists() {
+		return
+	}
+
+	ConfigGlobalsErr = LoadConfigGlobals()
+}
 
-var ValidProjects = GetProjects()
-var ValidEnvs = GetEnvironments()
-var ValidAppComponents = GetAppComponents()
-var ProjectProviders = GetProjectProviders(ValidProjects, ValidEnvs)
-var BucketName = GetBucketName()
+// LoadConfigGlobals reads the projects, environments, providers and bucket name of the config file into the
+// config globals
+func LoadConfigGlobals() error {
+	var err error
+
+	ValidProjects, err = GetProjects()
+	if err != nil {
+		return err
+	}
+
+	ValidEnvs, err = GetEnvironments()
+	if err != nil {
+		return err
+	}
+
+	ProjectProviders, err = GetProjectProviders(ValidProjects)
+	if err != nil {
+		return err
+	}
+
+	BucketName, err = GetBucketName()
+	return err
+}
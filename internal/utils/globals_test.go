@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+const testListConfig = `[ENVIRONMENTS]
+environments = dev,prod
+
+["front"]
+environments = dev,preview
+dev.provider = AWS
+dev.branch_name = develop
+preview.provider = DGO
+preview.app_name = front-app
+preview.app_component_name = web
+
+["back"]
+environments = prod
+prod.provider = OCI
+prod.namespace = apps
+prod.configmap_name = back-config
+prod.secret_name = back-secret
+`
+
+func TestGetProjectProviders(t *testing.T) {
+	setTestConfig(t, testListConfig)
+
+	got, err := GetProjectProviders([]string{"front", "back"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{"front": {"AWS", "DGO"}, "back": {"OCI"}}
+	if len(got) != len(want) {
+		t.Fatalf("GetProjectProviders() = %v, want %v", got, want)
+	}
+	for _, projectProvider := range got {
+		if !slices.Equal(projectProvider.CloudProvider, want[projectProvider.Name]) {
+			t.Errorf("GetProjectProviders() providers of %s = %v, want %v", projectProvider.Name, projectProvider.CloudProvider, want[projectProvider.Name])
+		}
+	}
+}
+
+func TestGetProjectEnvironmentConfig(t *testing.T) {
+	setTestConfig(t, testListConfig)
+
+	tests := []struct {
+		project     string
+		environment string
+		want        ProjectEnvironmentConfig
+	}{
+		{
+			project: "front", environment: "dev",
+			want: ProjectEnvironmentConfig{Project: "front", Environment: "dev", Provider: "AWS", BranchName: "develop"},
+		},
+		{
+			project: "front", environment: "preview",
+			want: ProjectEnvironmentConfig{Project: "front", Environment: "preview", Provider: "DGO", AppName: "front-app", AppComponentName: "web"},
+		},
+		{
+			project: "back", environment: "prod",
+			want: ProjectEnvironmentConfig{Project: "back", Environment: "prod", Provider: "OCI", Namespace: "apps", ConfigMapName: "back-config", SecretName: "back-secret"},
+		},
+		{
+			project: "back", environment: "missing",
+			want: ProjectEnvironmentConfig{Project: "back", Environment: "missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.project+"/"+tt.environment, func(t *testing.T) {
+			if got := GetProjectEnvironmentConfig(tt.project, tt.environment); got != tt.want {
+				t.Errorf("GetProjectEnvironmentConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	"sigs.k8s.io/yaml"
+)
+
+var ValidImportFormats = []string{"dotenv", "json", "yaml", "k8s"}
+
+// dotenvKey matches the keys accepted in a dotenv line
+var dotenvKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// k8sManifest is the part of a ConfigMap or Secret manifest with environment variables
+type k8sManifest struct {
+	Kind       string            `json:"kind"`
+	Data       map[string]string `json:"data"`
+	StringData map[string]string `json:"stringData"`
+}
+
+// DetectImportFormat returns the format of a file from its extension, telling YAML maps and Kubernetes manifests
+// apart by their "apiVersion" and "kind" keys. Files with other extensions are read as dotenv.
+func DetectImportFormat(filePath string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		var fields map[string]any
+		if yaml.Unmarshal(content, &fields) == nil && fields["apiVersion"] != nil && fields["kind"] != nil {
+			return "k8s"
+		}
+		return "yaml"
+	default:
+		return "dotenv"
+	}
+}
+
+// ParseEnvs reads the environment variables of a file in one of the ValidImportFormats
+func ParseEnvs(content []byte, format string) (*ini.File, error) {
+	switch format {
+	case "dotenv":
+		return ParseDotenv(content)
+	case "json":
+		return parseJSONEnvs(content)
+	case "yaml":
+		jsonContent, err := yaml.YAMLToJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding YAML: %w", err)
+		}
+		return parseJSONEnvs(jsonContent)
+	case "k8s":
+		envFile, _, err := ParseK8sManifest(content)
+		return envFile, err
+	default:
+		return nil, fmt.Errorf("invalid format \"%s\". Options are: %v", format, ValidImportFormats)
+	}
+}
+
+// ParseDotenv reads a dotenv file. Lines can start with "export", values can be single quoted (literal), double
+// quoted (with backslash escapes) or unquoted (up to a " #" comment), and quoted values can span several lines.
+func ParseDotenv(content []byte) (*ini.File, error) {
+	return ParseDotenvWithKeys(content, nil)
+}
+
+// ParseDotenvWithKeys reads a dotenv file like ParseDotenv, also accepting the known keys that aren't valid dotenv
+// keys, such as keys a provider already stores
+func ParseDotenvWithKeys(content []byte, knownKeys []string) (*ini.File, error) {
+	envFile := ini.Empty()
+	input := strings.ReplaceAll(string(content), "\r\n", "\n")
+	lineNumber := 0
+
+	for len(input) > 0 {
+		var line string
+		line, input, _ = strings.Cut(input, "\n")
+		lineNumber++
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || (!dotenvKey.MatchString(key) && !slices.Contains(knownKeys, key)) {
+			return nil, fmt.Errorf("line %d: expected <key>=<value>", lineNumber)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			if comment := strings.Index(" "+value, " #"); comment >= 0 {
+				value = value[:comment]
+			}
+			envFile.Section("").Key(key).SetValue(strings.TrimSpace(value))
+			continue
+		}
+
+		// Quoted values end at the closing quote, which can be in a following line
+		startLine := lineNumber
+		quote := value[0]
+		rest := value[1:] + "\n" + input
+		var unquoted strings.Builder
+		closed := false
+
+		for i := 0; i < len(rest); i++ {
+			char := rest[i]
+			if char == '\n' {
+				lineNumber++
+			}
+
+			if char == quote {
+				closed = true
+				input = rest[i+1:]
+				break
+			}
+
+			if quote == '"' && char == '\\' && i+1 < len(rest) {
+				i++
+				switch rest[i] {
+				case 'n':
+					unquoted.WriteByte('\n')
+				case 'r':
+					unquoted.WriteByte('\r')
+				case 't':
+					unquoted.WriteByte('\t')
+				case '\n':
+					lineNumber++
+					unquoted.WriteByte('\n')
+				default:
+					unquoted.WriteByte(rest[i])
+				}
+				continue
+			}
+
+			unquoted.WriteByte(char)
+		}
+
+		if !closed {
+			return nil, fmt.Errorf("line %d: value of \"%s\" has no closing quote", startLine, key)
+		}
+
+		// Only a comment can follow the closing quote
+		var trailing string
+		trailing, input, _ = strings.Cut(input, "\n")
+		trailing = strings.TrimSpace(trailing)
+		if trailing != "" && !strings.HasPrefix(trailing, "#") {
+			return nil, fmt.Errorf("line %d: unexpected \"%s\" after the value of \"%s\"", lineNumber, trailing, key)
+		}
+
+		envFile.Section("").Key(key).SetValue(unquoted.String())
+	}
+
+	return envFile, nil
+}
+
+// parseJSONEnvs reads a JSON object, sorted by key. Numbers and booleans are kept as written, and nested values are refused.
+func parseJSONEnvs(content []byte) (*ini.File, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var fields map[string]any
+	err := decoder.Decode(&fields)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding object: %w", err)
+	}
+
+	envFile := ini.Empty()
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		switch value := fields[key].(type) {
+		case string:
+			envFile.Section("").Key(key).SetValue(value)
+		case json.Number, bool:
+			envFile.Section("").Key(key).SetValue(fmt.Sprint(value))
+		case nil:
+			envFile.Section("").Key(key).SetValue("")
+		default:
+			return nil, fmt.Errorf("value of \"%s\" must be a string, a number or a boolean", key)
+		}
+	}
+
+	return envFile, nil
+}
+
+// ParseK8sManifest reads the data of a ConfigMap or Secret manifest, sorted by key, decoding the base64 "data" of Secrets.
+// Returns the type the manifest holds: envs for a ConfigMap and secrets for a Secret.
+func ParseK8sManifest(content []byte) (*ini.File, string, error) {
+	var manifest k8sManifest
+	err := yaml.Unmarshal(content, &manifest)
+	if err != nil {
+		return nil, "", fmt.Errorf("error decoding Kubernetes manifest: %w", err)
+	}
+
+	envFile := ini.Empty()
+
+	switch manifest.Kind {
+	case "ConfigMap":
+		for _, key := range slices.Sorted(maps.Keys(manifest.Data)) {
+			envFile.Section("").Key(key).SetValue(manifest.Data[key])
+		}
+		return envFile, "envs", nil
+
+	case "Secret":
+		for _, key := range slices.Sorted(maps.Keys(manifest.Data)) {
+			decodedValue, err := base64.StdEncoding.DecodeString(manifest.Data[key])
+			if err != nil {
+				return nil, "", fmt.Errorf("value of \"%s\" is not valid base64: %w", key, err)
+			}
+			envFile.Section("").Key(key).SetValue(string(decodedValue))
+		}
+		// As in Kubernetes, stringData takes precedence over data
+		for _, key := range slices.Sorted(maps.Keys(manifest.StringData)) {
+			envFile.Section("").Key(key).SetValue(manifest.StringData[key])
+		}
+		return envFile, "secrets", nil
+
+	default:
+		return nil, "", fmt.Errorf("invalid kind \"%s\". Only ConfigMap and Secret manifests can be imported", manifest.Kind)
+	}
+}
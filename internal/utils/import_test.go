@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseEnvs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "dotenv",
+			content: "# comment\nA=1\n\nexport B = two words # comment\nC=\nD=#x\n",
+			format:  "dotenv",
+			want:    map[string]string{"A": "1", "B": "two words", "C": "", "D": ""},
+		},
+		{
+			name:    "dotenv quoted",
+			content: "A=\"say \\\"hi\\\" \\$HOME\\n\" # comment\nB='it \"is\" \\n'\nC=\"a#b\"\n",
+			format:  "dotenv",
+			want:    map[string]string{"A": "say \"hi\" $HOME\n", "B": "it \"is\" \\n", "C": "a#b"},
+		},
+		{
+			name:    "dotenv multiline",
+			content: "KEY=\"-----BEGIN KEY-----\nabc\n-----END KEY-----\"\nB=2\r\n",
+			format:  "dotenv",
+			want:    map[string]string{"KEY": "-----BEGIN KEY-----\nabc\n-----END KEY-----", "B": "2"},
+		},
+		{
+			name:    "dotenv unclosed quote",
+			content: "A=\"abc\nB=2\n",
+			format:  "dotenv",
+			wantErr: true,
+		},
+		{
+			name:    "dotenv text after quote",
+			content: "A=\"abc\" def\n",
+			format:  "dotenv",
+			wantErr: true,
+		},
+		{
+			name:    "dotenv invalid line",
+			content: "just text\n",
+			format:  "dotenv",
+			wantErr: true,
+		},
+		{
+			name:    "json",
+			content: `{"A": "1", "PORT": 8080, "DEBUG": true, "EMPTY": null}`,
+			format:  "json",
+			want:    map[string]string{"A": "1", "PORT": "8080", "DEBUG": "true", "EMPTY": ""},
+		},
+		{
+			name:    "json nested",
+			content: `{"A": {"B": "1"}}`,
+			format:  "json",
+			wantErr: true,
+		},
+		{
+			name:    "yaml",
+			content: "A: x\nPORT: 8080\nMULTI: |\n  line1\n  line2\n",
+			format:  "yaml",
+			want:    map[string]string{"A": "x", "PORT": "8080", "MULTI": "line1\nline2\n"},
+		},
+		{
+			name:    "k8s configmap",
+			content: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\ndata:\n  A: \"1\"\n",
+			format:  "k8s",
+			want:    map[string]string{"A": "1"},
+		},
+		{
+			name:    "k8s secret",
+			content: "apiVersion: v1\nkind: Secret\nmetadata:\n  name: app\ndata:\n  A: c2VjcmV0\n  B: b2xk\nstringData:\n  B: new\n",
+			format:  "k8s",
+			want:    map[string]string{"A": "secret", "B": "new"},
+		},
+		{
+			name:    "k8s invalid base64",
+			content: "apiVersion: v1\nkind: Secret\ndata:\n  A: '!!'\n",
+			format:  "k8s",
+			wantErr: true,
+		},
+		{
+			name:    "k8s invalid kind",
+			content: "apiVersion: apps/v1\nkind: Deployment\n",
+			format:  "k8s",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEnvs([]byte(tt.content), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEnvs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !maps.Equal(got.Section("").KeysHash(), tt.want) {
+				t.Errorf("ParseEnvs() = %v, want %v", got.Section("").KeysHash(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDotenvExportRoundTrip(t *testing.T) {
+	envs := map[string]string{
+		"PLAIN":     "value",
+		"SPACES":    "two words",
+		"QUOTES":    `say "hi" and 'bye'`,
+		"MULTILINE": "line1\nline2\r\n",
+		"SPECIAL":   "$HOME `cmd` \\ # \t",
+		"EMPTY":     "",
+	}
+
+	exported, err := FormatEnvs(newTestEnvFile(t, envs), Target{}, "dotenv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParseDotenv(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !maps.Equal(got.Section("").KeysHash(), envs) {
+		t.Errorf("ParseDotenv(FormatEnvs()) = %v, want %v", got.Section("").KeysHash(), envs)
+	}
+}
+
+func TestDetectImportFormat(t *testing.T) {
+	tests := []struct {
+		filePath string
+		content  string
+		want     string
+	}{
+		{filePath: ".env", want: "dotenv"},
+		{filePath: "envs.txt", want: "dotenv"},
+		{filePath: "envs.JSON", want: "json"},
+		{filePath: "envs.yaml", content: "A: 1\n", want: "yaml"},
+		{filePath: "secret.yml", content: "apiVersion: v1\nkind: Secret\n", want: "k8s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			if got := DetectImportFormat(tt.filePath, []byte(tt.content)); got != tt.want {
+				t.Errorf("DetectImportFormat() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/ini.v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Manifest is the desired state of the environment variables of several project environments
+type Manifest struct {
+	// SecretsFile is a dotenv file with the values referenced by "ref", relative to the manifest
+	SecretsFile  string                `json:"secrets_file"`
+	Environments []ManifestEnvironment `json:"environments"`
+}
+
+// ManifestEnvironment is the desired state of the envs and secrets of a project environment. A type that is
+// not set is not managed by the manifest.
+type ManifestEnvironment struct {
+	Project     string                   `json:"project"`
+	Environment string                   `json:"environment"`
+	Envs        map[string]ManifestValue `json:"envs"`
+	Secrets     map[string]ManifestValue `json:"secrets"`
+}
+
+// ManifestValue is the value of a variable in a manifest: a literal value, a "ref" to a key of the secrets
+// file or an "env" to read from the environment of the process
+type ManifestValue struct {
+	Value string `json:"-"`
+	Ref   string `json:"ref"`
+	Env   string `json:"env"`
+}
+
+// UnmarshalJSON reads a literal value, keeping numbers and booleans as written, or an object with a reference
+func (v *ManifestValue) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	err := decoder.Decode(&value)
+	if err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case string:
+		v.Value = value
+	case json.Number, bool:
+		v.Value = fmt.Sprint(value)
+	case nil:
+		v.Value = ""
+	case map[string]any:
+		type reference ManifestValue
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		var ref reference
+		err := decoder.Decode(&ref)
+		if err != nil {
+			return fmt.Errorf("invalid reference: %w", err)
+		}
+		if (ref.Ref == "") == (ref.Env == "") {
+			return fmt.Errorf("a reference must have either \"ref\" or \"env\"")
+		}
+		v.Ref, v.Env = ref.Ref, ref.Env
+	default:
+		return fmt.Errorf("a value must be a string, a number, a boolean or a reference")
+	}
+
+	return nil
+}
+
+// IsReference checks if the value comes from the secrets file or the environment
+func (v ManifestValue) IsReference() bool {
+	return v.Ref != "" || v.Env != ""
+}
+
+// ManifestTarget is a target managed by a manifest with its desired environment variables
+type ManifestTarget struct {
+	Target Target
+	Envs   *ini.File
+}
+
+// LoadManifest reads and validates a manifest file. Secrets must be references, so their values are never
+// written in the manifest.
+func LoadManifest(filePath string) (*Manifest, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest: %w", err)
+	}
+
+	var manifest Manifest
+	err = yaml.UnmarshalStrict(content, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding manifest: %w", err)
+	}
+
+	if manifest.SecretsFile != "" && !filepath.IsAbs(manifest.SecretsFile) {
+		manifest.SecretsFile = filepath.Join(filepath.Dir(filePath), manifest.SecretsFile)
+	}
+
+	seenEnvironments := map[string]bool{}
+	for i, manifestEnv := range manifest.Environments {
+		if manifestEnv.Project == "" || manifestEnv.Environment == "" {
+			return nil, fmt.Errorf("environment %d of the manifest must have a project and an environment", i+1)
+		}
+
+		key := manifestEnv.Project + "/" + manifestEnv.Environment
+		if seenEnvironments[key] {
+			return nil, fmt.Errorf("project \"%s\" in \"%s\" environment is in the manifest more than once", manifestEnv.Project, manifestEnv.Environment)
+		}
+		seenEnvironments[key] = true
+
+		for _, envName := range slices.Sorted(maps.Keys(manifestEnv.Secrets)) {
+			if !manifestEnv.Secrets[envName].IsReference() {
+				return nil, fmt.Errorf("secret \"%s\" of project \"%s\" in \"%s\" environment must be a reference (ref or env), not a value", envName, manifestEnv.Project, manifestEnv.Environment)
+			}
+		}
+	}
+
+	return &manifest, nil
+}
+
+// GetTargets resolves the references of the manifest and returns its targets, in the order of the manifest
+// with envs before secrets
+func (m *Manifest) GetTargets() ([]ManifestTarget, error) {
+	secretsFile := ini.Empty()
+	if m.SecretsFile != "" {
+		content, err := os.ReadFile(m.SecretsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading secrets file: %w", err)
+		}
+
+		secretsFile, err = ParseDotenv(content)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing secrets file \"%s\": %w", m.SecretsFile, err)
+		}
+	}
+
+	var targets []ManifestTarget
+	for _, manifestEnv := range m.Environments {
+		for _, envType := range ValidTypes {
+			manifestValues := manifestEnv.Envs
+			if envType == "secrets" {
+				manifestValues = manifestEnv.Secrets
+			}
+			if manifestValues == nil {
+				continue
+			}
+
+			target := Target{Project: manifestEnv.Project, Environment: manifestEnv.Environment, EnvType: envType}
+			envFile := ini.Empty()
+			for _, envName := range slices.Sorted(maps.Keys(manifestValues)) {
+				value, err := resolveManifestValue(manifestValues[envName], secretsFile)
+				if err != nil {
+					return nil, fmt.Errorf("%s \"%s\" of project \"%s\" in \"%s\" environment: %w", envType, envName, target.Project, target.Environment, err)
+				}
+				envFile.Section("").Key(envName).SetValue(value)
+			}
+
+			targets = append(targets, ManifestTarget{Target: target, Envs: envFile})
+		}
+	}
+
+	return targets, nil
+}
+
+// FindTypelessConflict returns the target of managedTargets that a target can't be managed with: another type of the
+// same project environment, when the provider keeps all types together and each type would delete the other
+func FindTypelessConflict(provider Provider, target Target, managedTargets []Target) (Target, bool) {
+	if SeparatesTypes(provider, target) {
+		return Target{}, false
+	}
+
+	for _, managedTarget := range managedTargets {
+		if managedTarget.Project == target.Project && managedTarget.Environment == target.Environment && managedTarget.EnvType != target.EnvType {
+			return managedTarget, true
+		}
+	}
+
+	return Target{}, false
+}
+
+// resolveManifestValue returns a literal value or the value of a reference
+func resolveManifestValue(manifestValue ManifestValue, secretsFile *ini.File) (string, error) {
+	switch {
+	case manifestValue.Ref != "":
+		if !secretsFile.Section("").HasKey(manifestValue.Ref) {
+			return "", fmt.Errorf("reference \"%s\" not found in the secrets file", manifestValue.Ref)
+		}
+		return secretsFile.Section("").Key(manifestValue.Ref).Value(), nil
+	case manifestValue.Env != "":
+		value, ok := os.LookupEnv(manifestValue.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable \"%s\" is not set", manifestValue.Env)
+		}
+		return value, nil
+	default:
+		return manifestValue.Value, nil
+	}
+}
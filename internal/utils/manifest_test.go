@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManifestGetTargets(t *testing.T) {
+	t.Setenv("TEST_API_TOKEN", "token-from-env")
+
+	tests := []struct {
+		name     string
+		manifest string
+		secrets  string
+		want     map[string]map[string]string
+		wantErr  bool
+	}{
+		{
+			name: "values and references",
+			manifest: `secrets_file: secrets.env
+environments:
+  - project: back
+    environment: prod
+    envs:
+      API_URL: https://api.example.com
+      PORT: 8080
+      DEBUG: false
+      EMPTY:
+    secrets:
+      DB_PASSWORD: {ref: PROD_DB_PASSWORD}
+      API_TOKEN:
+        env: TEST_API_TOKEN
+  - project: front
+    environment: dev
+    envs: {}
+`,
+			secrets: "PROD_DB_PASSWORD=\"p4ss word\"\n",
+			want: map[string]map[string]string{
+				"back/prod/envs":    {"API_URL": "https://api.example.com", "DEBUG": "false", "EMPTY": "", "PORT": "8080"},
+				"back/prod/secrets": {"API_TOKEN": "token-from-env", "DB_PASSWORD": "p4ss word"},
+				"front/dev/envs":    {},
+			},
+		},
+		{
+			name: "secret value in the manifest",
+			manifest: `environments:
+  - project: back
+    environment: prod
+    secrets:
+      DB_PASSWORD: p4ss
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing reference",
+			manifest: `secrets_file: secrets.env
+environments:
+  - project: back
+    environment: prod
+    secrets:
+      DB_PASSWORD: {ref: MISSING}
+`,
+			secrets: "OTHER=1\n",
+			wantErr: true,
+		},
+		{
+			name: "unset environment variable",
+			manifest: `environments:
+  - project: back
+    environment: prod
+    secrets:
+      DB_PASSWORD: {env: TEST_UNSET_VARIABLE}
+`,
+			wantErr: true,
+		},
+		{
+			name: "reference with ref and env",
+			manifest: `environments:
+  - project: back
+    environment: prod
+    envs:
+      A: {ref: A, env: A}
+`,
+			wantErr: true,
+		},
+		{
+			name: "nested value",
+			manifest: `environments:
+  - project: back
+    environment: prod
+    envs:
+      A: [1, 2]
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			manifest: `environments:
+  - project: back
+    environment: prod
+    variables:
+      A: 1
+`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate environment",
+			manifest: `environments:
+  - project: back
+    environment: prod
+  - project: back
+    environment: prod
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing project",
+			manifest: `environments:
+  - environment: prod
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			manifestPath := filepath.Join(dir, "env-manager.yaml")
+			if err := os.WriteFile(manifestPath, []byte(tt.manifest), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if tt.secrets != "" {
+				if err := os.WriteFile(filepath.Join(dir, "secrets.env"), []byte(tt.secrets), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			manifest, err := LoadManifest(manifestPath)
+			var targets []ManifestTarget
+			if err == nil {
+				targets, err = manifest.GetTargets()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadManifest() and GetTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := map[string]map[string]string{}
+			for _, target := range targets {
+				got[target.Target.Project+"/"+target.Target.Environment+"/"+target.Target.EnvType] = target.Envs.Section("").KeysHash()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTypelessConflict(t *testing.T) {
+	envs := Target{Project: "front", Environment: "dev", EnvType: "envs"}
+	secrets := Target{Project: "front", Environment: "dev", EnvType: "secrets"}
+	otherSecrets := Target{Project: "front", Environment: "prod", EnvType: "secrets"}
+
+	tests := []struct {
+		name           string
+		provider       Provider
+		target         Target
+		managedTargets []Target
+		wantConflict   bool
+	}{
+		{name: "DigitalOcean envs and secrets", provider: &DGOProvider{}, target: secrets, managedTargets: []Target{envs}},
+		{name: "separate Vault paths", provider: &VaultProvider{PathTemplate: "{project}/{environment}/{type}"}, target: secrets, managedTargets: []Target{envs}},
+		{name: "AWS Amplify envs and secrets", provider: &AWSProvider{}, target: secrets, managedTargets: []Target{envs}, wantConflict: true},
+		{name: "shared Vault path", provider: &VaultProvider{PathTemplate: "{project}/{environment}"}, target: secrets, managedTargets: []Target{envs}, wantConflict: true},
+		{name: "AWS Amplify in other environments", provider: &AWSProvider{}, target: otherSecrets, managedTargets: []Target{envs}},
+		{name: "first type", provider: &AWSProvider{}, target: envs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FindTypelessConflict(tt.provider, tt.target, tt.managedTargets)
+			if ok != tt.wantConflict {
+				t.Fatalf("FindTypelessConflict() = %+v, %v, want conflict %v", got, ok, tt.wantConflict)
+			}
+			if ok && !reflect.DeepEqual(got, tt.managedTargets[0]) {
+				t.Errorf("FindTypelessConflict() = %+v, want %+v", got, tt.managedTargets[0])
+			}
+		})
+	}
+}
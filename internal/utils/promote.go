@@ -0,0 +1,41 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	"gopkg.in/ini.v1"
+)
+
+// LoadPromotedEnvs reads the environment variables of a source to copy to another environment: all of them when
+// envNames is empty, otherwise the given ones. The names not found in the source are skipped with a warning.
+// DigitalOcean secrets are refused, since only their encrypted values can be read.
+func LoadPromotedEnvs(provider Provider, source Target, envNames []string) (*ini.File, error) {
+	// DigitalOcean only returns the encrypted values of secrets, which can't be used outside the app
+	if HasEncryptedValues(provider, source.EnvType) {
+		return nil, fmt.Errorf("secrets of %s are encrypted by DigitalOcean and can't be copied", provider.Describe(source))
+	}
+
+	sourceEnvs, err := provider.Load(source)
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s: %w", provider.Describe(source), err)
+	}
+
+	if len(envNames) == 0 {
+		return sourceEnvs, nil
+	}
+
+	promotedEnvs := ini.Empty()
+	for _, envName := range envNames {
+		if !sourceEnvs.Section("").HasKey(envName) {
+			fmt.Printf("[WARNING] Environment variable \"%s\" not found in project \"%s\" in \"%s\" environment\n", envName, source.Project, source.Environment)
+			continue
+		}
+		promotedEnvs.Section("").Key(envName).SetValue(sourceEnvs.Section("").Key(envName).Value())
+	}
+
+	return promotedEnvs, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadPromotedEnvs(t *testing.T) {
+	setTestConfig(t, "")
+
+	tests := []struct {
+		name         string
+		providerName string
+		envType      string
+		envNames     []string
+		want         map[string]string
+		wantErr      bool
+	}{
+		{name: "all", providerName: "FILE", envType: "envs", want: map[string]string{"A": "1", "B": "2", "C": "3"}},
+		{name: "given names", providerName: "FILE", envType: "envs", envNames: []string{"A", "C"}, want: map[string]string{"A": "1", "C": "3"}},
+		{name: "missing names are skipped", providerName: "FILE", envType: "envs", envNames: []string{"A", "MISSING"}, want: map[string]string{"A": "1"}},
+		{name: "secrets", providerName: "FILE", envType: "secrets", want: map[string]string{"A": "1", "B": "2", "C": "3"}},
+		{name: "DigitalOcean envs", providerName: "DGO", envType: "envs", want: map[string]string{"A": "1", "B": "2", "C": "3"}},
+		{name: "DigitalOcean secrets are refused", providerName: "DGO", envType: "secrets", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &namedProvider{FileProvider: FileProvider{Path: t.TempDir()}, name: tt.providerName}
+			source := Target{Project: "p1", Environment: "dev", EnvType: tt.envType}
+			err := provider.FileProvider.Save(source, newTestEnvFile(t, map[string]string{"A": "1", "B": "2", "C": "3"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadPromotedEnvs(provider, source, tt.envNames)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPromotedEnvs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Section("").KeysHash(), tt.want) {
+				t.Errorf("LoadPromotedEnvs() = %v, want %v", got.Section("").KeysHash(), tt.want)
+			}
+		})
+	}
+}
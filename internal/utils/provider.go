@@ -0,0 +1,246 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gopkg.in/ini.v1"
+)
+
+// Target identifies the set of environment variables or secrets of a project environment
+type Target struct {
+	Project     string
+	Environment string
+	EnvType     string
+	// VariableScopes are the DGO variable scopes to set, by variable name. Other variables keep their scope.
+	VariableScopes map[string]string
+}
+
+// Provider is an interface that defines the methods that an environment variable backend should implement
+type Provider interface {
+	// Name returns the provider name as used in the "<environment>.provider" config value
+	Name() string
+	// Load reads the full key-value set of a target
+	Load(target Target) (*ini.File, error)
+	// Save replaces the full key-value set of a target
+	Save(target Target, envFile *ini.File) error
+	// Describe returns a human readable description of where the target is stored
+	Describe(target Target) string
+}
+
+// ProviderFactory creates a Provider with its cloud client
+type ProviderFactory func() (Provider, error)
+
+var providerRegistry = map[string]ProviderFactory{}
+
+// RegisterProvider registers a provider factory by the name used in the "<environment>.provider" config value
+func RegisterProvider(name string, factory ProviderFactory) {
+	if _, ok := providerRegistry[name]; ok {
+		panic(fmt.Sprintf("provider \"%s\" is already registered", name))
+	}
+	providerRegistry[name] = factory
+}
+
+// RegisteredProviders returns the sorted names of all registered providers
+func RegisteredProviders() []string {
+	var names []string
+	for name := range providerRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetProviderByName creates the provider registered with the given name. In dry-run mode, the provider prints
+// the changes of Save instead of saving them.
+func GetProviderByName(name string) (Provider, error) {
+	factory, ok := providerRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid provider \"%s\". Options are: %v", name, RegisteredProviders())
+	}
+
+	provider, err := factory()
+	if err != nil || !DryRun {
+		return provider, err
+	}
+
+	return newDryRunProvider(provider), nil
+}
+
+// GetProvider creates the provider configured in "<environment>.provider" for a project environment
+func GetProvider(project string, projEnvironment string) (Provider, error) {
+	providerName, err := GetConfigProperty(project, projEnvironment+".provider")
+	if err != nil {
+		return nil, err
+	}
+
+	return GetProviderByName(providerName)
+}
+
+// LoadTarget creates the provider configured for a target and reads its key-value set
+func LoadTarget(target Target) (Provider, *ini.File, error) {
+	provider, err := GetProvider(target.Project, target.Environment)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting provider: %w", err)
+	}
+
+	envFile, err := provider.Load(target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading %s: %w", provider.Describe(target), err)
+	}
+
+	return provider, envFile, nil
+}
+
+// SaveTarget saves the key-value set of a target and, if isK8s is set, then mirrors the changed and deleted keys to
+// the ConfigMap or Secret configured for it. The cluster is only changed after the provider saves, so a save the
+// provider refuses never leaves Kubernetes out of sync with the stored variables.
+func SaveTarget(provider Provider, target Target, envFile *ini.File, isK8s bool, changedEnvs *ini.File, deletedEnvs []string) error {
+	err := provider.Save(target, envFile)
+	if err != nil {
+		return fmt.Errorf("error saving %s: %w", provider.Describe(target), err)
+	}
+
+	if !isK8s {
+		return nil
+	}
+
+	if changedEnvs != nil && len(changedEnvs.Section("").Keys()) > 0 {
+		err = UpdateK8sTarget(target, changedEnvs)
+		if err != nil {
+			return fmt.Errorf("%s was saved, but updating %s failed: %w", provider.Describe(target), DescribeK8sTarget(target), err)
+		}
+	}
+
+	if len(deletedEnvs) > 0 {
+		err = DeleteK8sTargetKeys(target, deletedEnvs)
+		if err != nil {
+			return fmt.Errorf("%s was saved, but deleting keys from %s failed: %w", provider.Describe(target), DescribeK8sTarget(target), err)
+		}
+	}
+
+	return nil
+}
+
+// LoadEffectiveEnvs reads the environment variables a target sees. For providers with inherited levels, they are the
+// merged levels, and the levels of each variable are also returned.
+func LoadEffectiveEnvs(provider Provider, target Target) (*ini.File, map[string][]string, error) {
+	layeredProvider, ok := provider.(LayeredProvider)
+	if !ok {
+		envFile, err := provider.Load(target)
+		return envFile, nil, err
+	}
+
+	layers, err := layeredProvider.LoadLayers(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envFile, envLevels := MergeEnvLayers(layers)
+	return envFile, envLevels, nil
+}
+
+// LoadEnvTypes reads the effective environment variables of several types of a project environment into a single
+// set. A key can only be in more than one type with the same value, as with providers that don't separate secrets.
+// DigitalOcean secrets are refused, since only their encrypted values can be read.
+func LoadEnvTypes(project string, projEnvironment string, envTypes []string) (*ini.File, error) {
+	provider, err := GetProvider(project, projEnvironment)
+	if err != nil {
+		return nil, fmt.Errorf("error getting provider: %w", err)
+	}
+
+	mergedEnvs := ini.Empty()
+	for _, envType := range envTypes {
+		target := Target{Project: project, Environment: projEnvironment, EnvType: envType}
+		if envType == "secrets" && provider.Name() == "DGO" {
+			return nil, fmt.Errorf("secrets of %s are encrypted by DigitalOcean and can't be used outside the app", provider.Describe(target))
+		}
+
+		envFile, _, err := LoadEffectiveEnvs(provider, target)
+		if err != nil {
+			return nil, fmt.Errorf("error loading %s: %w", provider.Describe(target), err)
+		}
+
+		for _, key := range envFile.Section("").Keys() {
+			if mergedEnvs.Section("").HasKey(key.Name()) && mergedEnvs.Section("").Key(key.Name()).Value() != key.Value() {
+				return nil, fmt.Errorf("\"%s\" is set with different values in more than one type of project \"%s\" in \"%s\" environment", key.Name(), project, projEnvironment)
+			}
+			mergedEnvs.Section("").Key(key.Name()).SetValue(key.Value())
+		}
+	}
+
+	return mergedEnvs, nil
+}
+
+// EnvLayer is a named level of environment variables, such as the app or the component of a DGO App
+type EnvLayer struct {
+	Name string
+	Envs *ini.File
+}
+
+// LayeredProvider is implemented by providers whose targets inherit environment variables from other levels
+type LayeredProvider interface {
+	Provider
+	// LoadLayers reads the levels a target inherits from, from the most general to the target itself
+	LoadLayers(target Target) ([]EnvLayer, error)
+}
+
+// EnvVersion is a stored version of the key-value set of a target
+type EnvVersion struct {
+	ID   string
+	Time time.Time
+	// IsDeleted is set for the versions that mark the target as deleted, which have no key-value set
+	IsDeleted bool
+}
+
+// VersionedProvider is implemented by providers that keep the previous versions of a target
+type VersionedProvider interface {
+	Provider
+	// ListVersions returns the versions of a target, from the newest to the oldest
+	ListVersions(target Target) ([]EnvVersion, error)
+	// LoadVersion reads the key-value set of a target in a version
+	LoadVersion(target Target, versionID string) (*ini.File, error)
+}
+
+// ValidatingProvider is implemented by providers that refuse some key-value sets, so dry runs can report the refusals
+type ValidatingProvider interface {
+	Provider
+	// Validate returns the error Save would return for a key-value set it refuses, without saving anything
+	Validate(target Target, envFile *ini.File) error
+}
+
+// TypelessProvider is implemented by providers that can keep the envs and secrets of a target in the same
+// key-value set, so each type loads and saves all of them
+type TypelessProvider interface {
+	Provider
+	// SeparatesTypes returns whether the envs and secrets of a target are stored apart
+	SeparatesTypes(target Target) bool
+}
+
+// SeparatesTypes returns whether a provider stores the envs and secrets of a target apart, which all providers do
+// unless they implement TypelessProvider
+func SeparatesTypes(provider Provider, target Target) bool {
+	typelessProvider, ok := provider.(TypelessProvider)
+	return !ok || typelessProvider.SeparatesTypes(target)
+}
+
+// MergeEnvLayers merges layers into the effective key-value set, where later layers override earlier ones.
+// It also returns, for each key, the names of the layers that define it.
+func MergeEnvLayers(layers []EnvLayer) (*ini.File, map[string][]string) {
+	mergedEnvs := ini.Empty()
+	envLevels := make(map[string][]string)
+
+	for _, layer := range layers {
+		for _, key := range layer.Envs.Section("").Keys() {
+			mergedEnvs.Section("").Key(key.Name()).SetValue(key.Value())
+			envLevels[key.Name()] = append(envLevels[key.Name()], layer.Name)
+		}
+	}
+
+	return mergedEnvs, envLevels
+}
@@ -0,0 +1,192 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/amplify"
+	"github.com/aws/aws-sdk-go-v2/service/amplify/types"
+	"github.com/oracle/oci-go-sdk/v49/common"
+	"gopkg.in/ini.v1"
+)
+
+// AWSProvider stores environment variables in the branches of AWS Amplify apps, or in the apps themselves
+type AWSProvider struct {
+	Client *amplify.Client
+}
+
+func init() {
+	RegisterProvider("AWS", NewAWSProvider)
+}
+
+// NewAWSProvider creates an AWSProvider from the [AWS] config section
+func NewAWSProvider() (Provider, error) {
+	configProvider, _, err := GetConfigProviderAWS()
+	if err != nil {
+		return nil, fmt.Errorf("error getting config provider: %w", err)
+	}
+
+	return &AWSProvider{Client: amplify.NewFromConfig(configProvider)}, nil
+}
+
+// Name returns the provider name
+func (p *AWSProvider) Name() string {
+	return "AWS"
+}
+
+// Load reads the environment variables of the branch configured in "<environment>.branch_name",
+// or the app-level environment variables when the target has the app scope
+func (p *AWSProvider) Load(target Target) (*ini.File, error) {
+	app, err := p.getApp(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsAppScope(target) {
+		return getAWSEnvsAsIni(app.EnvironmentVariables), nil
+	}
+
+	branchInfos, err := p.getBranch(target, app)
+	if err != nil {
+		return nil, err
+	}
+
+	return getAWSEnvsAsIni(branchInfos.Branch.EnvironmentVariables), nil
+}
+
+// LoadLayers reads the app-level environment variables and, unless the target has the app scope, the branch ones
+func (p *AWSProvider) LoadLayers(target Target) ([]EnvLayer, error) {
+	app, err := p.getApp(target)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := []EnvLayer{{Name: "app", Envs: getAWSEnvsAsIni(app.EnvironmentVariables)}}
+
+	if !IsAppScope(target) {
+		branchInfos, err := p.getBranch(target, app)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, EnvLayer{Name: "branch", Envs: getAWSEnvsAsIni(branchInfos.Branch.EnvironmentVariables)})
+	}
+
+	return layers, nil
+}
+
+// Save replaces the environment variables of the branch configured in "<environment>.branch_name",
+// or the app-level environment variables when the target has the app scope
+func (p *AWSProvider) Save(target Target, envFile *ini.File) error {
+	app, err := p.getApp(target)
+	if err != nil {
+		return err
+	}
+
+	if IsAppScope(target) {
+		_, err = p.Client.UpdateApp(context.Background(), &amplify.UpdateAppInput{
+			AppId:                app.AppId,
+			EnvironmentVariables: envFile.Section("").KeysHash(),
+		})
+		if err != nil {
+			return fmt.Errorf("error updating app: %w", err)
+		}
+
+		return nil
+	}
+
+	branchInfos, err := p.getBranch(target, app)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Client.UpdateBranch(context.Background(), &amplify.UpdateBranchInput{
+		AppId:                app.AppId,
+		BranchName:           branchInfos.Branch.BranchName,
+		EnvironmentVariables: envFile.Section("").KeysHash(),
+	})
+	if err != nil {
+		return fmt.Errorf("error updating branch: %w", err)
+	}
+
+	return nil
+}
+
+// Describe returns the Amplify app and branch of a target
+func (p *AWSProvider) Describe(target Target) string {
+	if IsAppScope(target) {
+		return fmt.Sprintf("AWS Amplify app \"%s\" (app-level)", target.Project)
+	}
+
+	branchName, err := GetConfigProperty(target.Project, target.Environment+".branch_name")
+	if err != nil {
+		branchName = target.Environment
+	}
+
+	return fmt.Sprintf("AWS Amplify app \"%s\" branch \"%s\"", target.Project, branchName)
+}
+
+// SeparatesTypes returns false, since Amplify keeps all the environment variables of a branch together
+func (p *AWSProvider) SeparatesTypes(target Target) bool {
+	return false
+}
+
+// getApp returns the Amplify app named after the target project
+func (p *AWSProvider) getApp(target Target) (*types.App, error) {
+	apps, err := p.Client.ListApps(context.Background(), &amplify.ListAppsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting apps: %w", err)
+	}
+
+	for _, app := range apps.Apps {
+		if *app.Name == target.Project {
+			return &app, nil
+		}
+	}
+
+	return nil, fmt.Errorf("app with project name \"%s\" not found", target.Project)
+}
+
+// getBranch returns the branch of an Amplify app configured in "<environment>.branch_name"
+func (p *AWSProvider) getBranch(target Target, app *types.App) (*amplify.GetBranchOutput, error) {
+	branchName, err := GetConfigProperty(target.Project, target.Environment+".branch_name")
+	if err != nil {
+		return nil, err
+	}
+
+	branchInfos, err := p.Client.GetBranch(context.Background(), &amplify.GetBranchInput{
+		AppId:      common.String(*app.AppId),
+		BranchName: common.String(branchName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting app in branch \"%s\": %w", branchName, err)
+	}
+
+	return branchInfos, nil
+}
+
+// IsAppScope checks if "<environment>.scope" is "app", meaning the app-level environment variables are used
+func IsAppScope(target Target) bool {
+	return GetConfigPropertyOrDefault(target.Project, target.Environment+".scope", "") == "app"
+}
+
+// getAWSEnvsAsIni converts Amplify environment variables to an ini.File sorted by name
+func getAWSEnvsAsIni(environmentVariables map[string]string) *ini.File {
+	envNames := make([]string, 0, len(environmentVariables))
+	for envName := range environmentVariables {
+		envNames = append(envNames, envName)
+	}
+	sort.Strings(envNames)
+
+	iniAWS := ini.Empty()
+	for _, envName := range envNames {
+		iniAWS.Section("").Key(envName).SetValue(environmentVariables[envName])
+	}
+
+	return iniAWS
+}
@@ -0,0 +1,238 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"gopkg.in/ini.v1"
+)
+
+// DGOProvider stores environment variables in the components (services, workers, jobs, static sites and functions) of
+// DigitalOcean apps. The "secrets" type is stored as variables with the SECRET type.
+type DGOProvider struct {
+	Client *godo.Client
+}
+
+func init() {
+	RegisterProvider("DGO", NewDGOProvider)
+}
+
+// NewDGOProvider creates a DGOProvider from the [DGO] config section
+func NewDGOProvider() (Provider, error) {
+	client, err := GetClientDGO()
+	if err != nil {
+		return nil, fmt.Errorf("error getting client: %w", err)
+	}
+
+	return &DGOProvider{Client: client}, nil
+}
+
+// Name returns the provider name
+func (p *DGOProvider) Name() string {
+	return "DGO"
+}
+
+// Load reads the environment variables of the component configured in "<environment>.app_component_name",
+// or the app-level environment variables when the target has the app scope
+func (p *DGOProvider) Load(target Target) (*ini.File, error) {
+	dgoApp, componentName, err := p.getApp(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if componentName == "" {
+		return GetDGOEnvsAsIni(dgoApp.Spec.Envs, target.EnvType), nil
+	}
+
+	component, err := GetDGOComponent(dgoApp.Spec, componentName)
+	if err != nil {
+		return nil, err
+	}
+
+	appEnvs, err := GetDGOComponentEnvs(component)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetDGOEnvsAsIni(appEnvs, target.EnvType), nil
+}
+
+// LoadLayers reads the app-level environment variables and, unless the target has the app scope, the component ones
+func (p *DGOProvider) LoadLayers(target Target) ([]EnvLayer, error) {
+	dgoApp, componentName, err := p.getApp(target)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := []EnvLayer{{Name: "app", Envs: GetDGOEnvsAsIni(dgoApp.Spec.Envs, target.EnvType)}}
+
+	if componentName != "" {
+		component, err := GetDGOComponent(dgoApp.Spec, componentName)
+		if err != nil {
+			return nil, err
+		}
+
+		appEnvs, err := GetDGOComponentEnvs(component)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, EnvLayer{Name: "component", Envs: GetDGOEnvsAsIni(appEnvs, target.EnvType)})
+	}
+
+	return layers, nil
+}
+
+// Save replaces the environment variables of the component configured in "<environment>.app_component_name",
+// or the app-level environment variables when the target has the app scope
+func (p *DGOProvider) Save(target Target, envFile *ini.File) error {
+	dgoApp, componentName, err := p.getApp(target)
+	if err != nil {
+		return err
+	}
+
+	if componentName == "" {
+		dgoApp.Spec.Envs, err = GetDGOEnvsFromIni(envFile, dgoApp.Spec.Envs, target.EnvType, target.VariableScopes)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = p.Client.Apps.Update(context.TODO(), dgoApp.ID, &godo.AppUpdateRequest{
+			Spec: dgoApp.Spec,
+		})
+		if err != nil {
+			return fmt.Errorf("error updating app: %w", err)
+		}
+
+		return nil
+	}
+
+	err = checkDGOAppComponents(componentName)
+	if err != nil {
+		return err
+	}
+
+	return UpdateDGOApp(p.Client, dgoApp, componentName, func(component godo.AppComponentSpec) (bool, error) {
+		appEnvs, err := GetDGOComponentEnvs(component)
+		if err != nil {
+			return false, err
+		}
+
+		appEnvs, err = GetDGOEnvsFromIni(envFile, appEnvs, target.EnvType, target.VariableScopes)
+		if err != nil {
+			return false, err
+		}
+
+		err = SetDGOComponentEnvs(component, appEnvs)
+		return err == nil, err
+	})
+}
+
+// Validate checks that the envs of a target can be saved, without saving them. A key can't be stored as both types,
+// and the components of earlier versions configured in [DGO.APP_COMPONENTS] are refused.
+func (p *DGOProvider) Validate(target Target, envFile *ini.File) error {
+	dgoApp, componentName, err := p.getApp(target)
+	if err != nil {
+		return err
+	}
+
+	appEnvs := dgoApp.Spec.Envs
+	if componentName != "" {
+		err = checkDGOAppComponents(componentName)
+		if err != nil {
+			return err
+		}
+
+		component, err := GetDGOComponent(dgoApp.Spec, componentName)
+		if err != nil {
+			return err
+		}
+
+		appEnvs, err = GetDGOComponentEnvs(component)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = GetDGOEnvsFromIni(envFile, appEnvs, target.EnvType, target.VariableScopes)
+	return err
+}
+
+// Describe returns the DigitalOcean app and component of a target
+func (p *DGOProvider) Describe(target Target) string {
+	dgoAppName, err := GetConfigProperty(target.Project, target.Environment+".app_name")
+	if err != nil {
+		dgoAppName = target.Project
+	}
+
+	appComponentName, err := GetDGOComponentName(target)
+	if err != nil {
+		return fmt.Sprintf("DigitalOcean app \"%s\"", dgoAppName)
+	} else if appComponentName == "" {
+		return fmt.Sprintf("DigitalOcean app \"%s\" (app-level)", dgoAppName)
+	}
+
+	return fmt.Sprintf("DigitalOcean app \"%s\" component \"%s\"", dgoAppName, appComponentName)
+}
+
+// getApp returns the app configured in "<environment>.app_name" and the configured component name,
+// which is empty when the target has the app scope
+func (p *DGOProvider) getApp(target Target) (*godo.App, string, error) {
+	dgoAppName, err := GetConfigProperty(target.Project, target.Environment+".app_name")
+	if err != nil {
+		return nil, "", err
+	}
+
+	appComponentName, err := GetDGOComponentName(target)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dgoApp, err := GetDGOApp(p.Client, dgoAppName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return dgoApp, appComponentName, nil
+}
+
+// checkDGOAppComponents refuses to save a component while the [DGO.APP_COMPONENTS] section of earlier versions lists
+// other components. Those versions wrote the same changes to every listed component, so saving only one of them
+// would silently leave the others behind.
+func checkDGOAppComponents(componentName string) error {
+	appComponents := GetConfigPropertyOrDefault("DGO.APP_COMPONENTS", "app_components", "")
+	for _, appComponent := range strings.Split(appComponents, ",") {
+		appComponent = strings.TrimSpace(appComponent)
+		if appComponent != "" && appComponent != componentName {
+			return fmt.Errorf("the [DGO.APP_COMPONENTS] section is no longer supported and component \"%s\" would not be changed. Configure an environment for each component with \"<environment>.app_component_name\" and remove the section", appComponent)
+		}
+	}
+
+	return nil
+}
+
+// GetDGOComponentName returns the component set in "<environment>.app_component_name". It's empty when
+// "<environment>.scope" is "app", meaning the app-level environment variables are used. An empty component name is
+// refused, so a typo can't switch a target to the variables of the whole app.
+func GetDGOComponentName(target Target) (string, error) {
+	if IsAppScope(target) {
+		return "", nil
+	}
+
+	appComponentName, err := GetConfigProperty(target.Project, target.Environment+".app_component_name")
+	if err != nil {
+		return "", err
+	}
+
+	if appComponentName == "" {
+		return "", fmt.Errorf("\"%s.app_component_name\" of project \"%s\" is empty. Set it to a component name, or set \"%s.scope = app\" to manage the app-level environment variables", target.Environment, target.Project, target.Environment)
+	}
+
+	return appComponentName, nil
+}
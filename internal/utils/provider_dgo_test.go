@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import "testing"
+
+func TestCheckDGOAppComponents(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        string
+		componentName string
+		wantErr       bool
+	}{
+		{name: "no section", config: "[DGO]\ndgo_api_token = token\n", componentName: "web"},
+		{name: "only the target component", config: "[DGO.APP_COMPONENTS]\napp_components = web\n", componentName: "web"},
+		{name: "other components", config: "[DGO.APP_COMPONENTS]\napp_components = web, admin\n", componentName: "web", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t, tt.config)
+
+			err := checkDGOAppComponents(tt.componentName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDGOAppComponents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetDGOComponentName(t *testing.T) {
+	setTestConfig(t, `["front"]
+environments = web,shared,typo,missing
+web.app_component_name = web
+shared.app_component_name = web
+shared.scope = app
+typo.app_component_name =
+`)
+
+	tests := []struct {
+		environment string
+		want        string
+		wantErr     bool
+	}{
+		{environment: "web", want: "web"},
+		{environment: "shared", want: ""},
+		{environment: "typo", wantErr: true},
+		{environment: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			got, err := GetDGOComponentName(Target{Project: "front", Environment: tt.environment})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDGOComponentName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetDGOComponentName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
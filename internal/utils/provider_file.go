@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/ini.v1"
+)
+
+// FileProvider stores environment variables as INI files in a local directory, using the same layout as OCIProvider
+type FileProvider struct {
+	Path string
+}
+
+func init() {
+	RegisterProvider("FILE", NewFileProvider)
+}
+
+// NewFileProvider creates a FileProvider from the [FILE] config section. The directory defaults to "~/.env-manager/files".
+func NewFileProvider() (Provider, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("error getting user home directory: %w", err)
+	}
+
+	path, err := ExpandHomeDir(GetConfigPropertyOrDefault("FILE", "path", filepath.Join(userHome, ".env-manager/files")))
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileProvider{Path: path}, nil
+}
+
+// Name returns the provider name
+func (p *FileProvider) Name() string {
+	return "FILE"
+}
+
+// Load reads the env file of a target. A missing file is an empty set of environment variables.
+func (p *FileProvider) Load(target Target) (*ini.File, error) {
+	envFilePath := p.getEnvFilePath(target)
+
+	if _, err := os.Stat(envFilePath); os.IsNotExist(err) {
+		return ini.Empty(), nil
+	}
+
+	envFile, err := ini.Load(envFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading file: %w", err)
+	}
+
+	return envFile, nil
+}
+
+// Save overwrites the env file of a target
+func (p *FileProvider) Save(target Target, envFile *ini.File) error {
+	envFileContent, err := IniToString(envFile)
+	if err != nil {
+		return fmt.Errorf("error converting file to string: %w", err)
+	}
+
+	envFilePath := p.getEnvFilePath(target)
+
+	err = os.MkdirAll(filepath.Dir(envFilePath), 0700)
+	if err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	err = os.WriteFile(envFilePath, []byte(envFileContent), 0600)
+	if err != nil {
+		return fmt.Errorf("error saving file: %w", err)
+	}
+
+	return nil
+}
+
+// Describe returns the file path of a target
+func (p *FileProvider) Describe(target Target) string {
+	return fmt.Sprintf("file \"%s\"", p.getEnvFilePath(target))
+}
+
+// getEnvFilePath returns the path of a target env file, as in "<path>/<project>/env-files/.<environment>_<type>"
+func (p *FileProvider) getEnvFilePath(target Target) string {
+	return filepath.Join(p.Path, GetEnvObjectName(target.Project, GetEnvFileName(target)))
+}
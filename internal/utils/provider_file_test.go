@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/ini.v1"
+)
+
+// setTestConfig creates a temporary home directory with the given config file
+func setTestConfig(t *testing.T, config string) string {
+	t.Helper()
+
+	userHome := t.TempDir()
+	t.Setenv("HOME", userHome)
+
+	err := os.MkdirAll(filepath.Join(userHome, ".env-manager"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(userHome, ".env-manager/config"), []byte(config), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return userHome
+}
+
+// newTestEnvFile creates an ini.File with the given environment variables
+func newTestEnvFile(t *testing.T, envs map[string]string) *ini.File {
+	t.Helper()
+
+	envFile := ini.Empty()
+	for name, value := range envs {
+		envFile.Section("").Key(name).SetValue(value)
+	}
+
+	return envFile
+}
+
+func TestNewFileProviderPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{name: "default", config: "", want: ".env-manager/files"},
+		{name: "empty", config: "[FILE]\npath =\n", want: ".env-manager/files"},
+		{name: "home", config: "[FILE]\npath = ~/envs\n", want: "envs"},
+		{name: "absolute", config: "[FILE]\npath = /srv/envs\n", want: "/srv/envs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userHome := setTestConfig(t, tt.config)
+
+			provider, err := NewFileProvider()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := tt.want
+			if !filepath.IsAbs(want) {
+				want = filepath.Join(userHome, want)
+			}
+
+			if got := provider.(*FileProvider).Path; got != want {
+				t.Errorf("Path = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFileProviderRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		target Target
+		envs   map[string]string
+	}{
+		{name: "envs", target: Target{Project: "proj", Environment: "dev", EnvType: "envs"}, envs: map[string]string{"FOO": "bar", "EMPTY": ""}},
+		{name: "secrets", target: Target{Project: "proj", Environment: "prod", EnvType: "secrets"}, envs: map[string]string{"TOKEN": "a=b;c#d"}},
+		{name: "multiline", target: Target{Project: "proj", Environment: "dev", EnvType: "secrets"}, envs: map[string]string{"KEY": "-----BEGIN KEY-----\nabc\n\n-----END KEY-----", "AFTER": "1", "QUOTE": "a`b\"c"}},
+		{name: "no envs", target: Target{Project: "other", Environment: "dev", EnvType: "envs"}, envs: map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &FileProvider{Path: t.TempDir()}
+
+			envFile, err := provider.Load(tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if keys := envFile.Section("").KeyStrings(); len(keys) != 0 {
+				t.Fatalf("Load of a missing file = %v, want no keys", keys)
+			}
+
+			err = provider.Save(tt.target, newTestEnvFile(t, tt.envs))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			envFilePath := filepath.Join(provider.Path, tt.target.Project, "env-files", "."+tt.target.Environment+"_"+tt.target.EnvType)
+			info, err := os.Stat(envFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+			}
+
+			envFile, err = provider.Load(tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := envFile.Section("").KeysHash()
+			if len(got) != len(tt.envs) {
+				t.Fatalf("Load = %v, want %v", got, tt.envs)
+			}
+			for name, value := range tt.envs {
+				if got[name] != value {
+					t.Errorf("%s = %q, want %q", name, got[name], value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v49/common"
+	"github.com/oracle/oci-go-sdk/v49/objectstorage"
+	"gopkg.in/ini.v1"
+)
+
+// OCIProvider stores environment variables as INI files in an OCI Object Storage bucket
+type OCIProvider struct {
+	Client     objectstorage.ObjectStorageClient
+	Namespace  string
+	BucketName string
+}
+
+func init() {
+	RegisterProvider("OCI", NewOCIProvider)
+}
+
+// NewOCIProvider creates an OCIProvider from the [OCI] config section
+func NewOCIProvider() (Provider, error) {
+	configProvider, _, err := GetConfigProviderOCI()
+	if err != nil {
+		return nil, fmt.Errorf("error getting config provider: %w", err)
+	}
+
+	ociNamespace, err := GetConfigProperty("OCI", "namespace")
+	if err != nil {
+		return nil, fmt.Errorf("error getting namespace: %w", err)
+	}
+
+	client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(configProvider)
+	if err != nil {
+		return nil, fmt.Errorf("error creating object storage client: %w", err)
+	}
+
+	return &OCIProvider{Client: client, Namespace: ociNamespace, BucketName: BucketName}, nil
+}
+
+// Name returns the provider name
+func (p *OCIProvider) Name() string {
+	return "OCI"
+}
+
+// Load reads the env file of a target from the bucket
+func (p *OCIProvider) Load(target Target) (*ini.File, error) {
+	return GetEnvsFileAsIni(target.Project, GetEnvFileName(target), p.Client, p.Namespace, p.BucketName)
+}
+
+// Save overwrites the env file of a target in the bucket
+func (p *OCIProvider) Save(target Target, envFile *ini.File) error {
+	return SaveEnvFile(p.Client, p.Namespace, target.Project, GetEnvFileName(target), envFile, p.BucketName)
+}
+
+// Describe returns the object path of a target
+func (p *OCIProvider) Describe(target Target) string {
+	return fmt.Sprintf("OCI object \"%s/%s\"", p.BucketName, GetEnvObjectName(target.Project, GetEnvFileName(target)))
+}
+
+// ListVersions returns the versions of the env file of a target kept by the bucket object versioning
+func (p *OCIProvider) ListVersions(target Target) ([]EnvVersion, error) {
+	bucketResponse, err := p.Client.GetBucket(context.Background(), objectstorage.GetBucketRequest{
+		NamespaceName: common.String(p.Namespace),
+		BucketName:    common.String(p.BucketName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting bucket: %w", err)
+	}
+	if bucketResponse.Versioning == objectstorage.BucketVersioningDisabled {
+		return nil, fmt.Errorf("object versioning is disabled in bucket \"%s\". Enable it to keep the previous versions of the env files", p.BucketName)
+	}
+
+	objectName := GetEnvObjectName(target.Project, GetEnvFileName(target))
+	request := objectstorage.ListObjectVersionsRequest{
+		NamespaceName: common.String(p.Namespace),
+		BucketName:    common.String(p.BucketName),
+		Prefix:        common.String(objectName),
+	}
+
+	var versions []EnvVersion
+	for {
+		response, err := p.Client.ListObjectVersions(context.Background(), request)
+		if err != nil {
+			return nil, fmt.Errorf("error listing object versions: %w", err)
+		}
+
+		for _, item := range response.Items {
+			// The prefix also matches other env files, such as ".dev_envs" and ".dev_envs_old"
+			if *item.Name != objectName {
+				continue
+			}
+			versions = append(versions, EnvVersion{ID: *item.VersionId, Time: item.TimeModified.Time, IsDeleted: *item.IsDeleteMarker})
+		}
+
+		if response.OpcNextPage == nil {
+			break
+		}
+		request.Page = response.OpcNextPage
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Time.After(versions[j].Time)
+	})
+
+	return versions, nil
+}
+
+// LoadVersion reads a version of the env file of a target from the bucket
+func (p *OCIProvider) LoadVersion(target Target, versionID string) (*ini.File, error) {
+	getResponse, err := p.Client.GetObject(context.Background(), objectstorage.GetObjectRequest{
+		NamespaceName: common.String(p.Namespace),
+		BucketName:    common.String(p.BucketName),
+		ObjectName:    common.String(GetEnvObjectName(target.Project, GetEnvFileName(target))),
+		VersionId:     common.String(versionID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting object version \"%s\": %w", versionID, err)
+	}
+	defer getResponse.Content.Close()
+
+	envFile, err := ini.Load(getResponse.Content)
+	if err != nil {
+		return nil, fmt.Errorf("error loading file: %w", err)
+	}
+
+	return envFile, nil
+}
+
+// GetEnvFileName returns the env file name of a target, as in "<environment>_<type>"
+func GetEnvFileName(target Target) string {
+	return fmt.Sprintf("%s_%s", target.Environment, target.EnvType)
+}
+
+// GetEnvObjectName returns the object name of an env file, as in "<project>/env-files/.<file-name>"
+func GetEnvObjectName(project string, fileName string) string {
+	return fmt.Sprintf("%s/env-files/.%s", project, fileName)
+}
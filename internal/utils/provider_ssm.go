@@ -0,0 +1,300 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	secretsmanagertypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"gopkg.in/ini.v1"
+)
+
+// SSMProvider stores environment variables as String parameters and secrets as SecureString parameters in
+// AWS Systems Manager Parameter Store. Secrets can be stored in AWS Secrets Manager instead.
+type SSMProvider struct {
+	Client               *ssm.Client
+	SecretsManagerClient *secretsmanager.Client
+}
+
+func init() {
+	RegisterProvider("SSM", NewSSMProvider)
+}
+
+// NewSSMProvider creates an SSMProvider from the [AWS] config section
+func NewSSMProvider() (Provider, error) {
+	configProvider, _, err := GetConfigProviderAWS()
+	if err != nil {
+		return nil, fmt.Errorf("error getting config provider: %w", err)
+	}
+
+	return &SSMProvider{
+		Client:               ssm.NewFromConfig(configProvider),
+		SecretsManagerClient: secretsmanager.NewFromConfig(configProvider),
+	}, nil
+}
+
+// Name returns the provider name
+func (p *SSMProvider) Name() string {
+	return "SSM"
+}
+
+// Load reads the parameters of a target, or its Secrets Manager secret
+func (p *SSMProvider) Load(target Target) (*ini.File, error) {
+	if p.isSecretsManager(target) {
+		return p.loadSecret(target)
+	}
+
+	allParameters, err := p.getParameters(target)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := p.filterParameters(target, allParameters)
+
+	envNames := make([]string, 0, len(parameters))
+	for envName := range parameters {
+		envNames = append(envNames, envName)
+	}
+	sort.Strings(envNames)
+
+	envFile := ini.Empty()
+	for _, envName := range envNames {
+		envFile.Section("").Key(envName).SetValue(parameters[envName])
+	}
+
+	return envFile, nil
+}
+
+// Save puts the new and changed parameters of a target and deletes the removed ones, or replaces its Secrets Manager secret
+func (p *SSMProvider) Save(target Target, envFile *ini.File) error {
+	if p.isSecretsManager(target) {
+		return p.saveSecret(target, envFile)
+	}
+
+	allParameters, err := p.getParameters(target)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkParameters(target, envFile, allParameters)
+	if err != nil {
+		return err
+	}
+
+	parameters := p.filterParameters(target, allParameters)
+	parameterPath := p.getParameterPath(target)
+	parameterType := p.getParameterType(target)
+
+	for _, key := range envFile.Section("").Keys() {
+		if value, ok := parameters[key.Name()]; ok && value == key.Value() {
+			continue
+		}
+
+		_, err = p.Client.PutParameter(context.Background(), &ssm.PutParameterInput{
+			Name:      aws.String(parameterPath + key.Name()),
+			Value:     aws.String(key.Value()),
+			Type:      parameterType,
+			Overwrite: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("error putting parameter \"%s\": %w", parameterPath+key.Name(), err)
+		}
+	}
+
+	var deletedNames []string
+	for envName := range parameters {
+		if !envFile.Section("").HasKey(envName) {
+			deletedNames = append(deletedNames, parameterPath+envName)
+		}
+	}
+
+	// DeleteParameters accepts at most 10 names per call
+	for start := 0; start < len(deletedNames); start += 10 {
+		end := min(start+10, len(deletedNames))
+		_, err = p.Client.DeleteParameters(context.Background(), &ssm.DeleteParametersInput{
+			Names: deletedNames[start:end],
+		})
+		if err != nil {
+			return fmt.Errorf("error deleting parameters: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the parameters of a target can be saved, without saving them
+func (p *SSMProvider) Validate(target Target, envFile *ini.File) error {
+	if p.isSecretsManager(target) {
+		return nil
+	}
+
+	allParameters, err := p.getParameters(target)
+	if err != nil {
+		return err
+	}
+
+	return p.checkParameters(target, envFile, allParameters)
+}
+
+// checkParameters refuses empty values, which SSM parameters don't accept, and keys stored as the other type. Envs
+// and secrets share the parameter path, so a key of the other type would be silently converted by the put.
+func (p *SSMProvider) checkParameters(target Target, envFile *ini.File, allParameters map[string]ssmtypes.Parameter) error {
+	parameterPath := p.getParameterPath(target)
+	parameterType := p.getParameterType(target)
+
+	for _, key := range envFile.Section("").Keys() {
+		if key.Value() == "" {
+			return fmt.Errorf("environment variable \"%s\" has an empty value, which SSM parameters don't accept", key.Name())
+		}
+
+		if parameter, ok := allParameters[key.Name()]; ok && parameter.Type != parameterType {
+			return fmt.Errorf("parameter \"%s\" already exists as %s. Delete it from the other type before saving it as %s", parameterPath+key.Name(), parameter.Type, parameterType)
+		}
+	}
+
+	return nil
+}
+
+// Describe returns the parameter path or the Secrets Manager secret of a target
+func (p *SSMProvider) Describe(target Target) string {
+	if p.isSecretsManager(target) {
+		return fmt.Sprintf("AWS Secrets Manager secret \"%s\"", p.getSecretName(target))
+	}
+
+	return fmt.Sprintf("AWS SSM %s parameters in \"%s\"", p.getParameterType(target), p.getParameterPath(target))
+}
+
+// getParameters returns the parameters of every type under the target path, by their names without the path
+func (p *SSMProvider) getParameters(target Target) (map[string]ssmtypes.Parameter, error) {
+	parameters := make(map[string]ssmtypes.Parameter)
+
+	paginator := ssm.NewGetParametersByPathPaginator(p.Client, &ssm.GetParametersByPathInput{
+		Path:           aws.String(p.getParameterPath(target)),
+		WithDecryption: aws.Bool(true),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error getting parameters: %w", err)
+		}
+
+		for _, parameter := range page.Parameters {
+			parameters[path.Base(aws.ToString(parameter.Name))] = parameter
+		}
+	}
+
+	return parameters, nil
+}
+
+// filterParameters returns the values of the parameters of the target type
+func (p *SSMProvider) filterParameters(target Target, allParameters map[string]ssmtypes.Parameter) map[string]string {
+	parameters := make(map[string]string)
+	isSecrets := p.getParameterType(target) == ssmtypes.ParameterTypeSecureString
+
+	for envName, parameter := range allParameters {
+		if (parameter.Type == ssmtypes.ParameterTypeSecureString) == isSecrets {
+			parameters[envName] = aws.ToString(parameter.Value)
+		}
+	}
+
+	return parameters
+}
+
+// loadSecret reads the JSON key-value Secrets Manager secret of a target. A missing secret is an empty set of secrets.
+func (p *SSMProvider) loadSecret(target Target) (*ini.File, error) {
+	envFile := ini.Empty()
+
+	secret, err := p.SecretsManagerClient.GetSecretValue(context.Background(), &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(p.getSecretName(target)),
+	})
+
+	var notFoundErr *secretsmanagertypes.ResourceNotFoundException
+	if errors.As(err, &notFoundErr) {
+		return envFile, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting secret: %w", err)
+	}
+
+	secrets := make(map[string]string)
+	err = json.Unmarshal([]byte(aws.ToString(secret.SecretString)), &secrets)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing secret \"%s\". It must be a JSON object with string values: %w", p.getSecretName(target), err)
+	}
+
+	envNames := make([]string, 0, len(secrets))
+	for envName := range secrets {
+		envNames = append(envNames, envName)
+	}
+	sort.Strings(envNames)
+
+	for _, envName := range envNames {
+		envFile.Section("").Key(envName).SetValue(secrets[envName])
+	}
+
+	return envFile, nil
+}
+
+// saveSecret writes a new version of the Secrets Manager secret of a target, creating it if needed
+func (p *SSMProvider) saveSecret(target Target, envFile *ini.File) error {
+	secretString, err := json.Marshal(envFile.Section("").KeysHash())
+	if err != nil {
+		return fmt.Errorf("error encoding secret: %w", err)
+	}
+
+	_, err = p.SecretsManagerClient.PutSecretValue(context.Background(), &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(p.getSecretName(target)),
+		SecretString: aws.String(string(secretString)),
+	})
+
+	var notFoundErr *secretsmanagertypes.ResourceNotFoundException
+	if errors.As(err, &notFoundErr) {
+		_, err = p.SecretsManagerClient.CreateSecret(context.Background(), &secretsmanager.CreateSecretInput{
+			Name:         aws.String(p.getSecretName(target)),
+			SecretString: aws.String(string(secretString)),
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("error saving secret: %w", err)
+	}
+
+	return nil
+}
+
+// isSecretsManager reports if the target secrets are stored in Secrets Manager, as set in "<environment>.secrets_backend"
+func (p *SSMProvider) isSecretsManager(target Target) bool {
+	secretsBackend := GetConfigPropertyOrDefault(target.Project, target.Environment+".secrets_backend", "ssm")
+	return target.EnvType == "secrets" && strings.EqualFold(secretsBackend, "secretsmanager")
+}
+
+// getParameterPath returns the parameter path of a target, set in "<environment>.ssm_path" or "/<project>/<environment>/"
+func (p *SSMProvider) getParameterPath(target Target) string {
+	parameterPath := GetConfigPropertyOrDefault(target.Project, target.Environment+".ssm_path", fmt.Sprintf("/%s/%s", target.Project, target.Environment))
+	return "/" + strings.Trim(parameterPath, "/") + "/"
+}
+
+// getParameterType returns the parameter type used for the target type
+func (p *SSMProvider) getParameterType(target Target) ssmtypes.ParameterType {
+	if target.EnvType == "secrets" {
+		return ssmtypes.ParameterTypeSecureString
+	}
+	return ssmtypes.ParameterTypeString
+}
+
+// getSecretName returns the Secrets Manager secret name of a target, which is the parameter path without slashes around it
+func (p *SSMProvider) getSecretName(target Target) string {
+	return strings.Trim(p.getParameterPath(target), "/")
+}
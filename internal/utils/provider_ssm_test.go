@@ -0,0 +1,179 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// fakeSSM is an SSM Parameter Store API that keeps parameters in memory and records the writes
+type fakeSSM struct {
+	parameters    map[string][2]string // name -> type, value
+	putNames      []string
+	deleteBatches [][]string
+}
+
+func (f *fakeSSM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Path  string
+		Name  string
+		Type  string
+		Value string
+		Names []string
+	}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	output := map[string]any{}
+	switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonSSM.") {
+	case "GetParametersByPath":
+		var parameters []map[string]string
+		for name, parameter := range f.parameters {
+			if strings.HasPrefix(name, input.Path) {
+				parameters = append(parameters, map[string]string{"Name": name, "Type": parameter[0], "Value": parameter[1]})
+			}
+		}
+		output["Parameters"] = parameters
+	case "PutParameter":
+		f.putNames = append(f.putNames, input.Name)
+		f.parameters[input.Name] = [2]string{input.Type, input.Value}
+		output["Version"] = 1
+	case "DeleteParameters":
+		f.deleteBatches = append(f.deleteBatches, input.Names)
+		for _, name := range input.Names {
+			delete(f.parameters, name)
+		}
+		output["DeletedParameters"] = input.Names
+	default:
+		http.Error(w, "unexpected action", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(output)
+}
+
+// newTestSSMProvider creates an SSMProvider whose client calls a fake SSM API
+func newTestSSMProvider(t *testing.T, fake *fakeSSM) *SSMProvider {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return &SSMProvider{Client: ssm.New(ssm.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("id", "secret", ""),
+	})}
+}
+
+func TestSSMProviderSave(t *testing.T) {
+	setTestConfig(t, "[PROJECTS]\nprojects = p1\n[\"p1\"]\nenvironments = dev\ndev.provider = SSM\n")
+
+	manyParameters := map[string][2]string{}
+	for i := 0; i < 23; i++ {
+		manyParameters[fmt.Sprintf("/p1/dev/VAR%02d", i)] = [2]string{"String", "x"}
+	}
+
+	tests := []struct {
+		name              string
+		envType           string
+		parameters        map[string][2]string
+		envs              map[string]string
+		wantErr           string
+		wantPutNames      []string
+		wantDeleteBatches []int
+	}{
+		{
+			name:         "puts only new and changed parameters",
+			envType:      "envs",
+			parameters:   map[string][2]string{"/p1/dev/A": {"String", "1"}, "/p1/dev/B": {"String", "2"}},
+			envs:         map[string]string{"A": "1", "B": "changed", "C": "3"},
+			wantPutNames: []string{"/p1/dev/B", "/p1/dev/C"},
+		},
+		{
+			name:       "empty value is refused",
+			envType:    "envs",
+			parameters: map[string][2]string{},
+			envs:       map[string]string{"A": "1", "EMPTY": ""},
+			wantErr:    "\"EMPTY\" has an empty value",
+		},
+		{
+			name:       "secret can't be saved as env",
+			envType:    "envs",
+			parameters: map[string][2]string{"/p1/dev/TOKEN": {"SecureString", "t"}},
+			envs:       map[string]string{"TOKEN": "t"},
+			wantErr:    "already exists as SecureString",
+		},
+		{
+			name:       "env can't be saved as secret",
+			envType:    "secrets",
+			parameters: map[string][2]string{"/p1/dev/HOST": {"String", "h"}},
+			envs:       map[string]string{"HOST": "h"},
+			wantErr:    "already exists as String",
+		},
+		{
+			name:       "secrets don't delete envs",
+			envType:    "secrets",
+			parameters: map[string][2]string{"/p1/dev/HOST": {"String", "h"}, "/p1/dev/TOKEN": {"SecureString", "t"}},
+			envs:       map[string]string{"TOKEN": "t"},
+		},
+		{
+			name:              "deletes are batched by 10",
+			envType:           "envs",
+			parameters:        manyParameters,
+			envs:              map[string]string{},
+			wantDeleteBatches: []int{10, 10, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeSSM{parameters: map[string][2]string{}}
+			for name, parameter := range tt.parameters {
+				fake.parameters[name] = parameter
+			}
+			provider := newTestSSMProvider(t, fake)
+
+			err := provider.Save(Target{Project: "p1", Environment: "dev", EnvType: tt.envType}, newTestEnvFile(t, tt.envs))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Save() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if len(fake.putNames) > 0 || len(fake.deleteBatches) > 0 {
+					t.Errorf("refused Save() wrote parameters: put %v, deleted %v", fake.putNames, fake.deleteBatches)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			slices.Sort(fake.putNames)
+			if !slices.Equal(fake.putNames, tt.wantPutNames) {
+				t.Errorf("put parameters = %v, want %v", fake.putNames, tt.wantPutNames)
+			}
+
+			var deleteBatches []int
+			for _, names := range fake.deleteBatches {
+				deleteBatches = append(deleteBatches, len(names))
+			}
+			if !slices.Equal(deleteBatches, tt.wantDeleteBatches) {
+				t.Errorf("delete batch sizes = %v, want %v", deleteBatches, tt.wantDeleteBatches)
+			}
+		})
+	}
+}
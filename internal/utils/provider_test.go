@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/ini.v1"
+)
+
+func TestMergeEnvLayers(t *testing.T) {
+	tests := []struct {
+		name       string
+		layers     []map[string]string
+		wantEnvs   map[string]string
+		wantLevels map[string][]string
+	}{
+		{
+			name:       "no layers",
+			wantEnvs:   map[string]string{},
+			wantLevels: map[string][]string{},
+		},
+		{
+			name:       "single layer",
+			layers:     []map[string]string{{"A": "1"}},
+			wantEnvs:   map[string]string{"A": "1"},
+			wantLevels: map[string][]string{"A": {"layer0"}},
+		},
+		{
+			name:       "later layer overrides",
+			layers:     []map[string]string{{"A": "app", "B": "app"}, {"A": "component", "C": "component"}},
+			wantEnvs:   map[string]string{"A": "component", "B": "app", "C": "component"},
+			wantLevels: map[string][]string{"A": {"layer0", "layer1"}, "B": {"layer0"}, "C": {"layer1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var layers []EnvLayer
+			for i, envs := range tt.layers {
+				layers = append(layers, EnvLayer{Name: fmt.Sprintf("layer%d", i), Envs: newTestEnvFile(t, envs)})
+			}
+
+			mergedEnvs, envLevels := MergeEnvLayers(layers)
+
+			got := mergedEnvs.Section("").KeysHash()
+			if len(got) != len(tt.wantEnvs) {
+				t.Fatalf("envs = %v, want %v", got, tt.wantEnvs)
+			}
+			for name, value := range tt.wantEnvs {
+				if got[name] != value {
+					t.Errorf("%s = %q, want %q", name, got[name], value)
+				}
+			}
+
+			if len(envLevels) != len(tt.wantLevels) {
+				t.Fatalf("levels = %v, want %v", envLevels, tt.wantLevels)
+			}
+			for name, levels := range tt.wantLevels {
+				if !slices.Equal(envLevels[name], levels) {
+					t.Errorf("levels of %s = %v, want %v", name, envLevels[name], levels)
+				}
+			}
+		})
+	}
+}
+
+// namedProvider is a FileProvider with the name of another provider, to test the provider specific behavior
+type namedProvider struct {
+	FileProvider
+	name string
+}
+
+func (p *namedProvider) Name() string {
+	return p.name
+}
+
+// failingProvider is a Provider whose Save always fails
+type failingProvider struct {
+	FileProvider
+}
+
+func (p *failingProvider) Save(target Target, envFile *ini.File) error {
+	return errors.New("refused")
+}
+
+func TestSaveTargetMirrorsK8sOnlyAfterSave(t *testing.T) {
+	// K8s isn't configured for p1, so any attempt to change the cluster fails
+	setTestConfig(t, "[PROJECTS]\nprojects = p1\n[\"p1\"]\nenvironments = dev\ndev.provider = FILE\n")
+	target := Target{Project: "p1", Environment: "dev", EnvType: "envs"}
+	changedEnvs := newTestEnvFile(t, map[string]string{"A": "1"})
+
+	tests := []struct {
+		name     string
+		provider Provider
+		isK8s    bool
+		wantErr  string
+	}{
+		{name: "save fails before changing the cluster", provider: &failingProvider{}, isK8s: true, wantErr: "error saving"},
+		{name: "cluster is changed after saving", provider: &FileProvider{Path: t.TempDir()}, isK8s: true, wantErr: "was saved, but updating"},
+		{name: "without k8s", provider: &FileProvider{Path: t.TempDir()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SaveTarget(tt.provider, target, changedEnvs, tt.isK8s, changedEnvs, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SaveTarget() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
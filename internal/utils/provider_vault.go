@@ -0,0 +1,142 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+	"gopkg.in/ini.v1"
+)
+
+// VaultProvider stores environment variables as secrets in a HashiCorp Vault KV v2 mount
+type VaultProvider struct {
+	Client       *vault.Client
+	MountPath    string
+	PathTemplate string
+}
+
+func init() {
+	RegisterProvider("VAULT", NewVaultProvider)
+}
+
+// NewVaultProvider creates a VaultProvider from the [VAULT] config section. It logs in with
+// AppRole when "role_id" and "secret_id" are set, otherwise it uses "token" or VAULT_TOKEN.
+func NewVaultProvider() (Provider, error) {
+	vaultConfig := vault.DefaultConfig()
+	if vaultConfig.Error != nil {
+		return nil, fmt.Errorf("error reading Vault environment: %w", vaultConfig.Error)
+	}
+
+	address := GetConfigPropertyOrDefault("VAULT", "address", "")
+	if address != "" {
+		vaultConfig.Address = address
+	}
+
+	client, err := vault.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Vault client: %w", err)
+	}
+
+	roleId := GetConfigPropertyOrDefault("VAULT", "role_id", "")
+	secretId := GetConfigPropertyOrDefault("VAULT", "secret_id", "")
+	token := GetConfigPropertyOrDefault("VAULT", "token", "")
+
+	if roleId != "" && secretId != "" {
+		approleMount := GetConfigPropertyOrDefault("VAULT", "approle_mount", "approle")
+		loginResponse, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", approleMount), map[string]interface{}{
+			"role_id":   roleId,
+			"secret_id": secretId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error logging in with AppRole: %w", err)
+		}
+		if loginResponse == nil || loginResponse.Auth == nil {
+			return nil, fmt.Errorf("error logging in with AppRole: no token returned")
+		}
+		client.SetToken(loginResponse.Auth.ClientToken)
+	} else if token != "" {
+		client.SetToken(token)
+	}
+
+	if client.Token() == "" {
+		return nil, fmt.Errorf("Vault token not found. Set \"token\" or \"role_id\" and \"secret_id\" in the VAULT config section")
+	}
+
+	return &VaultProvider{
+		Client:       client,
+		MountPath:    GetConfigPropertyOrDefault("VAULT", "mount_path", "secret"),
+		PathTemplate: GetConfigPropertyOrDefault("VAULT", "path_template", "{project}/{environment}/{type}"),
+	}, nil
+}
+
+// Name returns the provider name
+func (p *VaultProvider) Name() string {
+	return "VAULT"
+}
+
+// Load reads the secret of a target. A missing secret is an empty set of environment variables.
+func (p *VaultProvider) Load(target Target) (*ini.File, error) {
+	envFile := ini.Empty()
+
+	secret, err := p.Client.KVv2(p.MountPath).Get(context.Background(), p.getSecretPath(target))
+	if errors.Is(err, vault.ErrSecretNotFound) {
+		return envFile, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading secret: %w", err)
+	}
+
+	envNames := make([]string, 0, len(secret.Data))
+	for envName := range secret.Data {
+		envNames = append(envNames, envName)
+	}
+	sort.Strings(envNames)
+
+	for _, envName := range envNames {
+		envFile.Section("").Key(envName).SetValue(fmt.Sprint(secret.Data[envName]))
+	}
+
+	return envFile, nil
+}
+
+// Save writes a new version of the secret of a target
+func (p *VaultProvider) Save(target Target, envFile *ini.File) error {
+	data := make(map[string]interface{})
+	for _, key := range envFile.Section("").Keys() {
+		data[key.Name()] = key.Value()
+	}
+
+	_, err := p.Client.KVv2(p.MountPath).Put(context.Background(), p.getSecretPath(target), data)
+	if err != nil {
+		return fmt.Errorf("error writing secret: %w", err)
+	}
+
+	return nil
+}
+
+// Describe returns the secret path of a target
+func (p *VaultProvider) Describe(target Target) string {
+	return fmt.Sprintf("Vault secret \"%s/%s\"", p.MountPath, p.getSecretPath(target))
+}
+
+// SeparatesTypes returns whether the path template has {type}, without which all types share the same secret
+func (p *VaultProvider) SeparatesTypes(target Target) bool {
+	return strings.Contains(p.PathTemplate, "{type}")
+}
+
+// getSecretPath renders the path template of a target, replacing {project}, {environment} and {type}
+func (p *VaultProvider) getSecretPath(target Target) string {
+	replacer := strings.NewReplacer(
+		"{project}", target.Project,
+		"{environment}", target.Environment,
+		"{type}", target.EnvType,
+	)
+
+	return strings.Trim(replacer.Replace(p.PathTemplate), "/")
+}
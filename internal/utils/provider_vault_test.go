@@ -0,0 +1,68 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import "testing"
+
+func TestVaultProviderSecretPath(t *testing.T) {
+	target := Target{Project: "back-end", Environment: "prod", EnvType: "secrets"}
+
+	tests := []struct {
+		name         string
+		mountPath    string
+		pathTemplate string
+		want         string
+		wantDescribe string
+	}{
+		{
+			name:         "default template",
+			mountPath:    "secret",
+			pathTemplate: "{project}/{environment}/{type}",
+			want:         "back-end/prod/secrets",
+			wantDescribe: "Vault secret \"secret/back-end/prod/secrets\"",
+		},
+		{
+			name:         "custom order and prefix",
+			mountPath:    "kv",
+			pathTemplate: "apps/{environment}/{project}-{type}",
+			want:         "apps/prod/back-end-secrets",
+			wantDescribe: "Vault secret \"kv/apps/prod/back-end-secrets\"",
+		},
+		{
+			name:         "template without type",
+			mountPath:    "secret",
+			pathTemplate: "{project}/{environment}",
+			want:         "back-end/prod",
+			wantDescribe: "Vault secret \"secret/back-end/prod\"",
+		},
+		{
+			name:         "repeated placeholders",
+			mountPath:    "secret",
+			pathTemplate: "{project}/{project}-{environment}",
+			want:         "back-end/back-end-prod",
+			wantDescribe: "Vault secret \"secret/back-end/back-end-prod\"",
+		},
+		{
+			name:         "leading and trailing slashes are trimmed",
+			mountPath:    "secret",
+			pathTemplate: "/{project}/{environment}/{type}/",
+			want:         "back-end/prod/secrets",
+			wantDescribe: "Vault secret \"secret/back-end/prod/secrets\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &VaultProvider{MountPath: tt.mountPath, PathTemplate: tt.pathTemplate}
+
+			if got := provider.getSecretPath(target); got != tt.want {
+				t.Errorf("getSecretPath() = %q, want %q", got, tt.want)
+			}
+			if got := provider.Describe(target); got != tt.wantDescribe {
+				t.Errorf("Describe() = %q, want %q", got, tt.wantDescribe)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/ini.v1"
+)
+
+var ValidTemplateSyntaxes = []string{"go", "envsubst"}
+
+// envsubstVariable matches the ${VAR} references of an envsubst template. The $VAR form is left untouched, so
+// templates can keep the variables of nginx or shell scripts.
+var envsubstVariable = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// RenderTemplate renders a template with the environment variables of envFile, using Go text/template syntax,
+// as in {{ .KEY }}, or envsubst syntax, as in ${KEY}. Referencing a key that doesn't exist is an error.
+func RenderTemplate(name string, content []byte, envFile *ini.File, syntax string) ([]byte, error) {
+	envs := envFile.Section("").KeysHash()
+
+	switch syntax {
+	case "go":
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template: %w", err)
+		}
+
+		var output bytes.Buffer
+		err = tmpl.Execute(&output, envs)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering template: %w", err)
+		}
+
+		return output.Bytes(), nil
+	case "envsubst":
+		var missingEnvs []string
+		for i, line := range strings.Split(string(content), "\n") {
+			for _, match := range envsubstVariable.FindAllStringSubmatch(line, -1) {
+				if _, ok := envs[match[1]]; !ok {
+					missingEnvs = append(missingEnvs, fmt.Sprintf("%s (line %d)", match[1], i+1))
+				}
+			}
+		}
+		if len(missingEnvs) > 0 {
+			return nil, fmt.Errorf("error rendering template: %s references keys that don't exist: %s", name, strings.Join(missingEnvs, ", "))
+		}
+
+		return envsubstVariable.ReplaceAllFunc(content, func(match []byte) []byte {
+			return []byte(envs[string(match[2:len(match)-1])])
+		}), nil
+	default:
+		return nil, fmt.Errorf("invalid template syntax \"%s\". Options are: %v", syntax, ValidTemplateSyntaxes)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	envFile := newTestEnvFile(t, map[string]string{
+		"HOST":      "api.example.com",
+		"PORT":      "8080",
+		"app.name":  "back",
+		"MULTILINE": "a\nb",
+	})
+
+	tests := []struct {
+		name     string
+		template string
+		syntax   string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "go",
+			template: "server {{ .HOST }}:{{ .PORT }};\nname {{ index . \"app.name\" }}\n",
+			syntax:   "go",
+			want:     "server api.example.com:8080;\nname back\n",
+		},
+		{
+			name:     "go with functions",
+			template: "{{ if eq .PORT \"8080\" }}default{{ end }} {{ printf \"%q\" .MULTILINE }}",
+			syntax:   "go",
+			want:     "default \"a\\nb\"",
+		},
+		{
+			name:     "go missing key",
+			template: "host {{ .HOST }}\nuser {{ .DB_USER }}\n",
+			syntax:   "go",
+			wantErr:  `map has no entry for key "DB_USER"`,
+		},
+		{
+			name:     "go invalid template",
+			template: "{{ .HOST ",
+			syntax:   "go",
+			wantErr:  "error parsing template",
+		},
+		{
+			name:     "envsubst",
+			template: "proxy_pass http://${HOST}:${PORT};\nproxy_set_header Host $host;\n",
+			syntax:   "envsubst",
+			want:     "proxy_pass http://api.example.com:8080;\nproxy_set_header Host $host;\n",
+		},
+		{
+			name:     "envsubst multiline value",
+			template: "value=${MULTILINE}",
+			syntax:   "envsubst",
+			want:     "value=a\nb",
+		},
+		{
+			name:     "envsubst missing keys",
+			template: "${HOST}\n${DB_USER} ${DB_PASSWORD}\n",
+			syntax:   "envsubst",
+			wantErr:  "DB_USER (line 2), DB_PASSWORD (line 2)",
+		},
+		{
+			name:     "invalid syntax",
+			template: "",
+			syntax:   "jinja",
+			wantErr:  "invalid template syntax",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate("test.conf", []byte(tt.template), envFile, tt.syntax)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RenderTemplate() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("RenderTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
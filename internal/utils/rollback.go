@@ -0,0 +1,52 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	"gopkg.in/ini.v1"
+)
+
+// LoadRollback reads the version of a target to restore and the current key-value set it replaces. Versions that
+// mark the target as deleted can't be restored. When the newest version is a deletion, the target has no current
+// key-value set, so an empty one is returned instead of loading it.
+func LoadRollback(provider VersionedProvider, target Target, versionID string) (*ini.File, *ini.File, error) {
+	versions, err := provider.ListVersions(target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing versions of %s: %w", provider.Describe(target), err)
+	}
+
+	isVersionFound := false
+	for _, version := range versions {
+		if version.ID != versionID {
+			continue
+		}
+		if version.IsDeleted {
+			return nil, nil, fmt.Errorf("version \"%s\" of %s is a deletion and can't be restored", versionID, provider.Describe(target))
+		}
+		isVersionFound = true
+	}
+	if !isVersionFound {
+		return nil, nil, fmt.Errorf("version \"%s\" not found in %s. Use the history command to list the versions", versionID, provider.Describe(target))
+	}
+
+	versionEnvFile, err := provider.LoadVersion(target, versionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading version \"%s\" of %s: %w", versionID, provider.Describe(target), err)
+	}
+
+	// Versions are listed from the newest, so a target whose newest version is a deletion doesn't exist
+	if versions[0].IsDeleted {
+		return versionEnvFile, ini.Empty(), nil
+	}
+
+	envFile, err := provider.Load(target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading %s: %w", provider.Describe(target), err)
+	}
+
+	return versionEnvFile, envFile, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gopkg.in/ini.v1"
+)
+
+// versionedFileProvider is a FileProvider with stored versions, whose current key-value set is the newest version
+type versionedFileProvider struct {
+	FileProvider
+	versions    []EnvVersion
+	versionEnvs map[string]map[string]string
+	t           *testing.T
+}
+
+func (p *versionedFileProvider) Load(target Target) (*ini.File, error) {
+	if p.versions[0].IsDeleted {
+		return nil, errors.New("not found")
+	}
+	return newTestEnvFile(p.t, p.versionEnvs[p.versions[0].ID]), nil
+}
+
+func (p *versionedFileProvider) ListVersions(target Target) ([]EnvVersion, error) {
+	return p.versions, nil
+}
+
+func (p *versionedFileProvider) LoadVersion(target Target, versionID string) (*ini.File, error) {
+	return newTestEnvFile(p.t, p.versionEnvs[versionID]), nil
+}
+
+func TestLoadRollback(t *testing.T) {
+	target := Target{Project: "p1", Environment: "dev", EnvType: "envs"}
+	versionEnvs := map[string]map[string]string{"v3": {"A": "3"}, "v2": {"A": "2"}, "v1": {"A": "1", "B": "1"}}
+
+	tests := []struct {
+		name        string
+		versions    []EnvVersion
+		versionID   string
+		wantVersion map[string]string
+		wantCurrent map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "previous version",
+			versions:    []EnvVersion{{ID: "v3"}, {ID: "v2"}, {ID: "v1"}},
+			versionID:   "v1",
+			wantVersion: map[string]string{"A": "1", "B": "1"},
+			wantCurrent: map[string]string{"A": "3"},
+		},
+		{
+			name:        "after a deletion, the current set is empty",
+			versions:    []EnvVersion{{ID: "v4", IsDeleted: true}, {ID: "v3"}, {ID: "v2"}},
+			versionID:   "v3",
+			wantVersion: map[string]string{"A": "3"},
+			wantCurrent: map[string]string{},
+		},
+		{
+			name:      "deletion can't be restored",
+			versions:  []EnvVersion{{ID: "v3"}, {ID: "v2", IsDeleted: true}, {ID: "v1"}},
+			versionID: "v2",
+			wantErr:   true,
+		},
+		{
+			name:      "missing version",
+			versions:  []EnvVersion{{ID: "v3"}, {ID: "v2"}},
+			versionID: "v9",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &versionedFileProvider{versions: tt.versions, versionEnvs: versionEnvs, t: t}
+
+			versionEnvFile, envFile, err := LoadRollback(provider, target, tt.versionID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRollback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := versionEnvFile.Section("").KeysHash(); !reflect.DeepEqual(got, tt.wantVersion) {
+				t.Errorf("LoadRollback() version = %v, want %v", got, tt.wantVersion)
+			}
+			if got := envFile.Section("").KeysHash(); !reflect.DeepEqual(got, tt.wantCurrent) {
+				t.Errorf("LoadRollback() current = %v, want %v", got, tt.wantCurrent)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"gopkg.in/ini.v1"
+)
+
+// RunWithEnvs runs a command with the environment variables of envFile added to the current environment, overriding
+// the ones already set. Interrupt and termination signals are forwarded to the command. Returns the exit code of the
+// command, which is 128 plus the signal number when the command is killed by a signal, as in a shell.
+func RunWithEnvs(args []string, envFile *ini.File) (int, error) {
+	// Later values override earlier ones with the same name
+	environ := os.Environ()
+	for _, key := range envFile.Section("").Keys() {
+		environ = append(environ, key.Name()+"="+key.Value())
+	}
+
+	childCmd := exec.Command(args[0], args[1:]...)
+	childCmd.Env = environ
+	childCmd.Stdin = os.Stdin
+	childCmd.Stdout = os.Stdout
+	childCmd.Stderr = os.Stderr
+
+	err := childCmd.Start()
+	if err != nil {
+		return 0, fmt.Errorf("error running command: %w", err)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			childCmd.Process.Signal(sig)
+		}
+	}()
+
+	err = childCmd.Wait()
+	signal.Stop(signals)
+	close(signals)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// ExitCode is -1 when the command is killed by a signal
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error running command: %w", err)
+	}
+
+	return 0, nil
+}
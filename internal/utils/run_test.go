@@ -0,0 +1,38 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import "testing"
+
+func TestRunWithEnvs(t *testing.T) {
+	t.Setenv("RUN_TEST_OVERRIDDEN", "old")
+	envFile := newTestEnvFile(t, map[string]string{"RUN_TEST_VALUE": "stored", "RUN_TEST_OVERRIDDEN": "new"})
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantExitCode int
+		wantErr      bool
+	}{
+		{name: "success", args: []string{"true"}},
+		{name: "exit code", args: []string{"sh", "-c", "exit 3"}, wantExitCode: 3},
+		{name: "stored variables", args: []string{"sh", "-c", `test "$RUN_TEST_VALUE" = stored`}},
+		{name: "stored variables override the environment", args: []string{"sh", "-c", `test "$RUN_TEST_OVERRIDDEN" = new`}},
+		{name: "killed by a signal", args: []string{"sh", "-c", "kill -TERM $$"}, wantExitCode: 143},
+		{name: "command not found", args: []string{"env-manager-v2-missing-command"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode, err := RunWithEnvs(tt.args, envFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunWithEnvs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if exitCode != tt.wantExitCode {
+				t.Errorf("RunWithEnvs() = %d, want %d", exitCode, tt.wantExitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// EnvQuery matches environment variables by key name and value. Empty fields match everything.
+type EnvQuery struct {
+	// KeyPattern is a glob pattern, as in "DB_*", or a regular expression when IsKeyRegex is set
+	KeyPattern string
+	IsKeyRegex bool
+	// ValueHash is the hex encoded SHA-256 hash of the value, so secret values don't have to be typed
+	ValueHash string
+	// ValueSubstring is a substring of the value
+	ValueSubstring string
+
+	keyRegex *regexp.Regexp
+}
+
+// NewEnvQuery validates the key pattern and the value hash of a query
+func NewEnvQuery(keyPattern string, isKeyRegex bool, valueHash string, valueSubstring string) (*EnvQuery, error) {
+	query := &EnvQuery{KeyPattern: keyPattern, IsKeyRegex: isKeyRegex, ValueHash: strings.ToLower(valueHash), ValueSubstring: valueSubstring}
+
+	if keyPattern == "" && valueHash == "" && valueSubstring == "" {
+		return nil, fmt.Errorf("a key pattern or a value to search for is required")
+	}
+
+	if isKeyRegex {
+		keyRegex, err := regexp.Compile(keyPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key regular expression: %w", err)
+		}
+		query.keyRegex = keyRegex
+	} else if _, err := path.Match(keyPattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid key pattern \"%s\": %w", keyPattern, err)
+	}
+
+	if valueHash != "" && !regexp.MustCompile(`^[0-9a-f]{64}$`).MatchString(query.ValueHash) {
+		return nil, fmt.Errorf("invalid SHA-256 hash \"%s\": it must have 64 hexadecimal characters", valueHash)
+	}
+
+	return query, nil
+}
+
+// IsValueQuery checks if the query matches values by substring, which can't be used with secrets
+func (q *EnvQuery) IsValueQuery() bool {
+	return q.ValueSubstring != ""
+}
+
+// Match returns the names of the keys of an env file that match the query
+func (q *EnvQuery) Match(envFile *ini.File) []string {
+	var envNames []string
+	for _, key := range envFile.Section("").Keys() {
+		if q.matchKey(key.Name()) && q.matchValue(key.Value()) {
+			envNames = append(envNames, key.Name())
+		}
+	}
+
+	return envNames
+}
+
+// matchKey checks if a key name matches the key pattern
+func (q *EnvQuery) matchKey(name string) bool {
+	if q.keyRegex != nil {
+		return q.keyRegex.MatchString(name)
+	}
+	if q.KeyPattern == "" {
+		return true
+	}
+
+	isMatch, _ := path.Match(q.KeyPattern, name)
+	return isMatch
+}
+
+// matchValue checks if a value matches the value hash and substring
+func (q *EnvQuery) matchValue(value string) bool {
+	if q.ValueHash != "" && HashEnvValue(value) != q.ValueHash {
+		return false
+	}
+
+	return strings.Contains(value, q.ValueSubstring)
+}
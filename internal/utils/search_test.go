@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEnvQueryMatch(t *testing.T) {
+	envFile := newTestEnvFile(t, map[string]string{
+		"DB_HOST":     "db.internal",
+		"DB_PASSWORD": "s3cret",
+		"API_URL":     "https://db.internal/api",
+		"LOG_LEVEL":   "debug",
+	})
+
+	tests := []struct {
+		name           string
+		keyPattern     string
+		isKeyRegex     bool
+		valueHash      string
+		valueSubstring string
+		want           []string
+		wantErr        bool
+	}{
+		{name: "glob", keyPattern: "DB_*", want: []string{"DB_HOST", "DB_PASSWORD"}},
+		{name: "exact key", keyPattern: "LOG_LEVEL", want: []string{"LOG_LEVEL"}},
+		{name: "regex", keyPattern: "_(URL|HOST)$", isKeyRegex: true, want: []string{"API_URL", "DB_HOST"}},
+		{name: "value substring", valueSubstring: "db.internal", want: []string{"API_URL", "DB_HOST"}},
+		{name: "value hash", valueHash: HashEnvValue("s3cret"), want: []string{"DB_PASSWORD"}},
+		{name: "uppercase value hash", valueHash: strings.ToUpper(HashEnvValue("s3cret")), want: []string{"DB_PASSWORD"}},
+		{name: "key and value", keyPattern: "DB_*", valueSubstring: "internal", want: []string{"DB_HOST"}},
+		{name: "no match", keyPattern: "REDIS_*", want: nil},
+		{name: "empty query", wantErr: true},
+		{name: "invalid glob", keyPattern: "DB_[", wantErr: true},
+		{name: "invalid regex", keyPattern: "DB_(", isKeyRegex: true, wantErr: true},
+		{name: "invalid hash", valueHash: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := NewEnvQuery(tt.keyPattern, tt.isKeyRegex, tt.valueHash, tt.valueSubstring)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEnvQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := query.Match(envFile)
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"gopkg.in/ini.v1"
 	v1 "k8s.io/api/core/v1"
@@ -133,6 +134,78 @@ func DeleteK8sResourceKey(manager KubernetesResourceManager, resourceName string
 	return nil
 }
 
+// GetK8sResourceData reads all key-value pairs of a Kubernetes ConfigMap or Secret, sorted by key
+func GetK8sResourceData(manager KubernetesResourceManager, resourceName string) (*ini.File, error) {
+	obj, err := manager.Get(context.TODO(), resourceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting resource \"%s\": %v", resourceName, err)
+	}
+
+	data := make(map[string]string)
+	switch resource := obj.(type) {
+	case *v1.ConfigMap:
+		for key, value := range resource.Data {
+			data[key] = value
+		}
+
+	case *v1.Secret:
+		for key, value := range resource.Data {
+			data[key] = string(value)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type")
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	envFile := ini.Empty()
+	for _, key := range keys {
+		envFile.Section("").Key(key).SetValue(data[key])
+	}
+
+	return envFile, nil
+}
+
+// ReplaceK8sResourceData replaces all key-value pairs of a Kubernetes ConfigMap or Secret with the ones in envFile,
+// removing the keys that are not in it
+func ReplaceK8sResourceData(manager KubernetesResourceManager, envFile *ini.File, resourceName string) error {
+	obj, err := manager.Get(context.TODO(), resourceName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting resource \"%s\": %v", resourceName, err)
+	}
+
+	switch resource := obj.(type) {
+	case *v1.ConfigMap:
+		resource.Data = envFile.Section("").KeysHash()
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return manager.Update(context.TODO(), resource, metav1.UpdateOptions{})
+		})
+
+	case *v1.Secret:
+		resource.Data = make(map[string][]byte)
+		for _, key := range envFile.Section("").Keys() {
+			resource.Data[key.Name()] = []byte(key.Value())
+		}
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return manager.Update(context.TODO(), resource, metav1.UpdateOptions{})
+		})
+
+	default:
+		return fmt.Errorf("unsupported resource type")
+	}
+
+	if err != nil {
+		return fmt.Errorf("error replacing resource \"%s\" data: %v", resourceName, err)
+	}
+
+	return nil
+}
+
 type ProjectProvider struct {
 	Name          string
 	CloudProvider []string
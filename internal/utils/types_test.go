@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReplaceK8sResourceData(t *testing.T) {
+	tests := []struct {
+		name    string
+		current map[string]string
+		envs    map[string]string
+	}{
+		{name: "add, update and prune", current: map[string]string{"A": "1", "B": "2"}, envs: map[string]string{"A": "x", "C": "3"}},
+		{name: "empty resource", current: nil, envs: map[string]string{"A": "1"}},
+		{name: "prune all", current: map[string]string{"A": "1"}, envs: map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secretData := make(map[string][]byte)
+			for key, value := range tt.current {
+				secretData[key] = []byte(value)
+			}
+
+			client := fake.NewSimpleClientset(
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}, Data: tt.current},
+				&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns"}, Data: secretData},
+			)
+
+			managers := map[string]KubernetesResourceManager{
+				"cm":     &ConfigMapManager{Client: client, Namespace: "ns"},
+				"secret": &SecretManager{Client: client, Namespace: "ns"},
+			}
+
+			for resourceName, manager := range managers {
+				envFile, err := GetK8sResourceData(manager, resourceName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkEnvs(t, resourceName+" before", envFile.Section("").KeysHash(), tt.current)
+
+				err = ReplaceK8sResourceData(manager, newTestEnvFile(t, tt.envs), resourceName)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				envFile, err = GetK8sResourceData(manager, resourceName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkEnvs(t, resourceName+" after", envFile.Section("").KeysHash(), tt.envs)
+			}
+
+			configMap, err := client.CoreV1().ConfigMaps("ns").Get(context.TODO(), "cm", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkEnvs(t, "ConfigMap data", configMap.Data, tt.envs)
+		})
+	}
+}
@@ -11,18 +11,18 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/service/amplify"
 	"github.com/digitalocean/godo"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 
-	"github.com/oracle/oci-go-sdk/example/helpers"
 	"github.com/oracle/oci-go-sdk/v49/common"
 	"github.com/oracle/oci-go-sdk/v49/objectstorage"
 	"github.com/spf13/cobra"
@@ -42,7 +42,7 @@ func StringInSlice(a string, list []string) bool {
 	return false
 }
 
-// IniToString converts an ini.File to a string
+// IniToString converts an ini.File to a string. Multiline values are written between triple quotes and kept as is.
 func IniToString(iniFile *ini.File) (string, error) {
 	var buffer bytes.Buffer
 	_, err := iniFile.WriteTo(&buffer)
@@ -54,12 +54,21 @@ func IniToString(iniFile *ini.File) (string, error) {
 	iniString := buffer.String()
 
 	var result []string
+	isMultiline := false
 	for _, line := range strings.Split(iniString, "\n") {
+		if isMultiline {
+			result = append(result, line)
+			isMultiline = !strings.HasSuffix(line, `"""`)
+			continue
+		}
+
 		if strings.TrimSpace(line) != "" {
 			// Remove extra spaces around '='
 			parts := strings.SplitN(line, "=", 2)
 			if len(parts) == 2 {
-				result = append(result, strings.TrimSpace(parts[0])+"="+strings.TrimSpace(parts[1]))
+				value := strings.TrimSpace(parts[1])
+				result = append(result, strings.TrimSpace(parts[0])+"="+value)
+				isMultiline = strings.HasPrefix(value, `"""`) && (len(value) < 6 || !strings.HasSuffix(value, `"""`))
 			}
 		}
 	}
@@ -74,24 +83,24 @@ func GetEnvsFileAsIni(project string, fileName string, client objectstorage.Obje
 	getRequest := objectstorage.GetObjectRequest{
 		NamespaceName: &namespace,
 		BucketName:    common.String(BucketName),
-		ObjectName:    common.String(fmt.Sprintf("%s/env-files/.%s", project, fileName)),
+		ObjectName:    common.String(GetEnvObjectName(project, fileName)),
 	}
 
 	getResponse, err := client.GetObject(context.Background(), getRequest)
-	helpers.FatalIfError(err)
+	if err != nil {
+		return nil, fmt.Errorf("error getting object: %w", err)
+	}
+	defer getResponse.Content.Close()
 
 	// Read the object content
 	content, err := io.ReadAll(getResponse.Content)
 	if err != nil {
-		fmt.Println("Error reading object content: ", err)
-		return nil, err
+		return nil, fmt.Errorf("error reading object content: %w", err)
 	}
 
 	envFile, err := ini.Load(content)
-
 	if err != nil {
-		fmt.Println("Error loading file: ", err)
-		return nil, err
+		return nil, fmt.Errorf("error loading file: %w", err)
 	}
 
 	return envFile, nil
@@ -115,6 +124,25 @@ func GetFlagString(cmd *cobra.Command, name string, validOptions []string, isGet
 	return value, nil
 }
 
+// ParseEnvTypes parses a comma separated list of types, such as "envs,secrets". "all" means all ValidTypes.
+func ParseEnvTypes(value string) ([]string, error) {
+	if value == "all" {
+		return ValidTypes, nil
+	}
+
+	var envTypes []string
+	for _, envType := range strings.Split(strings.ReplaceAll(value, " ", ""), ",") {
+		if !StringInSlice(envType, ValidTypes) {
+			return nil, fmt.Errorf("invalid type \"%s\". Options are: %v or all", envType, ValidTypes)
+		}
+		if !StringInSlice(envType, envTypes) {
+			envTypes = append(envTypes, envType)
+		}
+	}
+
+	return envTypes, nil
+}
+
 // GetConfigProviderOCI returns a ConfigurationProvider for OCI
 func GetConfigProviderOCI() (common.ConfigurationProvider, string, error) {
 	userHome, err := os.UserHomeDir()
@@ -156,7 +184,7 @@ func GetConfigProviderAWS() (aws.Config, string, error) {
 		config.WithCredentialsProvider(awsCreds))
 
 	if err != nil {
-		log.Fatalf("unable to load SDK config, %v", err)
+		return aws.Config{}, "", fmt.Errorf("unable to load SDK config: %w", err)
 	}
 
 	return configProvider, configFileName, nil
@@ -222,12 +250,135 @@ func GetK8sResourceDataParams(k8sClient *kubernetes.Clientset, project string, p
 	}
 
 	if err != nil {
-		log.Fatalf("Error getting resource name: %v", err)
+		fmt.Println("Error getting resource name: ", err)
+		return nil, ""
 	}
 
 	return manager, resourceName
 }
 
+// UpdateK8sTarget creates or updates keys in the ConfigMap or Secret configured for a project environment.
+// In dry-run mode, it only prints the changes.
+func UpdateK8sTarget(target Target, envFile *ini.File) error {
+	if DryRun {
+		return printDryRunK8sChanges(target, envFile, nil, true)
+	}
+
+	manager, resourceName, err := getK8sTargetManager(target)
+	if err != nil {
+		return err
+	}
+
+	return UpdateK8sResourceData(manager, envFile, resourceName)
+}
+
+// DeleteK8sTargetKeys removes keys from the ConfigMap or Secret configured for a project environment.
+// In dry-run mode, it only prints the changes.
+func DeleteK8sTargetKeys(target Target, keys []string) error {
+	if DryRun {
+		return printDryRunK8sChanges(target, ini.Empty(), keys, true)
+	}
+
+	manager, resourceName, err := getK8sTargetManager(target)
+	if err != nil {
+		return err
+	}
+
+	return DeleteK8sResourceKey(manager, resourceName, keys)
+}
+
+// LoadK8sTarget reads all keys of the ConfigMap or Secret configured for a project environment
+func LoadK8sTarget(target Target) (*ini.File, error) {
+	manager, resourceName, err := getK8sTargetManager(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetK8sResourceData(manager, resourceName)
+}
+
+// ReplaceK8sTarget makes the ConfigMap or Secret configured for a project environment match envFile exactly.
+// In dry-run mode, it only prints the changes.
+func ReplaceK8sTarget(target Target, envFile *ini.File) error {
+	if DryRun {
+		return printDryRunK8sChanges(target, envFile, nil, false)
+	}
+
+	manager, resourceName, err := getK8sTargetManager(target)
+	if err != nil {
+		return err
+	}
+
+	return ReplaceK8sResourceData(manager, envFile, resourceName)
+}
+
+// DescribeK8sTarget returns the ConfigMap or Secret configured for a project environment, as in "ConfigMap <namespace>/<name>"
+func DescribeK8sTarget(target Target) string {
+	resourceKind, resourceKey := "ConfigMap", ".configmap_name"
+	if target.EnvType == "secrets" {
+		resourceKind, resourceKey = "Secret", ".secret_name"
+	}
+
+	namespace := GetConfigPropertyOrDefault(target.Project, target.Environment+".namespace", "")
+	resourceName := GetConfigPropertyOrDefault(target.Project, target.Environment+resourceKey, "")
+
+	return fmt.Sprintf("Kubernetes %s \"%s/%s\"", resourceKind, namespace, resourceName)
+}
+
+// IsK8sTargetConfigured checks if the namespace, ConfigMap and Secret are configured for a project environment
+func IsK8sTargetConfigured(project string, projEnvironment string) bool {
+	for _, property := range []string{".namespace", ".configmap_name", ".secret_name"} {
+		if GetConfigPropertyOrDefault(project, projEnvironment+property, "") == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getK8sTargetManager returns the resource manager and the name of the ConfigMap or Secret configured for a project environment
+func getK8sTargetManager(target Target) (KubernetesResourceManager, string, error) {
+	if !IsK8sTargetConfigured(target.Project, target.Environment) {
+		return nil, "", fmt.Errorf("Kubernetes resource not configured for project \"%s\" in \"%s\" environment", target.Project, target.Environment)
+	}
+
+	k8sClient, err := GetK8sClient()
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting Kubernetes client: %w", err)
+	}
+
+	manager, resourceName := GetK8sResourceDataParams(k8sClient, target.Project, target.Environment, target.EnvType)
+	if manager == nil {
+		return nil, "", fmt.Errorf("Kubernetes resource not configured for project \"%s\" in \"%s\" environment", target.Project, target.Environment)
+	}
+
+	return manager, resourceName, nil
+}
+
+// LoadUserEnvFile loads a user file with environment variables in INI format
+func LoadUserEnvFile(filePath string) (*ini.File, error) {
+	userEnvFile, err := ini.Load(filePath)
+	if err != nil {
+		if _, statErr := os.Stat(filePath); statErr == nil {
+			return nil, fmt.Errorf("%w. Are you sure the file are in INI format (<key>=<value>)?", err)
+		}
+		return nil, err
+	}
+
+	return userEnvFile, nil
+}
+
+// GetUserEnvs returns the environment variables given by the user, from a file or from a single name and value
+func GetUserEnvs(filePath string, envName string, envValue string) (*ini.File, error) {
+	if filePath != "" {
+		return LoadUserEnvFile(filePath)
+	}
+
+	userEnvFile := ini.Empty()
+	userEnvFile.Section("").Key(envName).SetValue(envValue)
+	return userEnvFile, nil
+}
+
 // GetCloudProvider returns the cloud provider for a given project
 func GetCloudProvider(project string, ProjectProviders []ProjectProvider) []string {
 	for _, provider := range ProjectProviders {
@@ -239,26 +390,26 @@ func GetCloudProvider(project string, ProjectProviders []ProjectProvider) []stri
 }
 
 // SaveEnvFile cast a ini.File to a string and saves it in OCI Object Storage
-func SaveEnvFile(client objectstorage.ObjectStorageClient, namespace string, project string, fileName string, envFile *ini.File, BucketName string) {
+func SaveEnvFile(client objectstorage.ObjectStorageClient, namespace string, project string, fileName string, envFile *ini.File, BucketName string) error {
 	envFileContent, err := IniToString(envFile)
 	if err != nil {
-		fmt.Println("Error converting file to string: ", err)
-		return
+		return fmt.Errorf("error converting file to string: %w", err)
 	}
 
 	// Save file
 	saveRequest := objectstorage.PutObjectRequest{
 		NamespaceName: &namespace,
 		BucketName:    common.String(BucketName),
-		ObjectName:    common.String(fmt.Sprintf("%s/env-files/.%s", project, fileName)),
+		ObjectName:    common.String(GetEnvObjectName(project, fileName)),
 		PutObjectBody: io.NopCloser(strings.NewReader(envFileContent)),
 	}
 
 	_, err = client.PutObject(context.Background(), saveRequest)
 	if err != nil {
-		fmt.Println("Error saving file: ", err)
-		return
+		return fmt.Errorf("error saving file: %w", err)
 	}
+
+	return nil
 }
 
 // GetUserPermission asks the user for permission to proceed
@@ -286,45 +437,6 @@ func GetUserPermission(message string) bool {
 	}
 }
 
-// HandleAWS handles the AWS Amplify environment variables and controlls the command function
-func HandleAWS(client *amplify.Client, project, projEnvironment string, isGetAll bool, filePath string, args []string, envName string, envValue string, isQuiet bool, command string) {
-	apps, err := client.ListApps(context.Background(), &amplify.ListAppsInput{})
-	var appId string
-	if err != nil {
-		fmt.Println("Error getting apps: ", err)
-		return
-	}
-
-	var branchInfos *amplify.GetBranchOutput
-
-	for _, app := range apps.Apps {
-		if *app.Name == project {
-			branchInfos, err = client.GetBranch(context.Background(), &amplify.GetBranchInput{
-				AppId:      common.String(*app.AppId),
-				BranchName: common.String(projEnvironment),
-			})
-			appId = *app.AppId
-			if err != nil {
-				fmt.Printf("Error getting app in branch \"%s\": %s", projEnvironment, err)
-				return
-			}
-		}
-	}
-
-	switch command {
-	case "create":
-		CreateAWSEnvs(branchInfos, client, project, projEnvironment, filePath, envName, envValue, appId)
-	case "get":
-		PrintAWSEnvs(branchInfos, project, projEnvironment, isGetAll, args)
-	case "delete":
-		DeleteAWSEnvs(branchInfos, client, project, projEnvironment, filePath, args, isQuiet, appId)
-	case "update":
-		UpdateAWSEnvs(branchInfos, client, project, projEnvironment, filePath, envName, envValue, appId)
-	default:
-		fmt.Println("Invalid command")
-	}
-}
-
 // GetConfigFileName returns the path to the config file
 func GetConfigFileName() string {
 	userHome, err := os.UserHomeDir()
@@ -342,13 +454,38 @@ func GetConfigFileName() string {
 	return configFileName
 }
 
+// ConfigFileExists checks if the config file was created
+func ConfigFileExists() bool {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+
+	_, err = os.Stat(fmt.Sprintf("%s/%s", userHome, ".env-manager/config"))
+	return err == nil
+}
+
+// ExpandHomeDir replaces a leading "~" in a path with the user home directory
+func ExpandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting user home directory: %w", err)
+	}
+
+	return filepath.Join(userHome, strings.TrimPrefix(path, "~")), nil
+}
+
 func GetConfigProperty(sectionName string, property string) (string, error) {
 	sectionName = "\"" + sectionName + "\""
 	configFileName := GetConfigFileName()
 
 	cfg, err := ini.Load(configFileName)
 	if err != nil {
-		log.Fatalf("Error loading config file: %v", err)
+		return "", fmt.Errorf("error loading config file: %w", err)
 	}
 
 	if !cfg.Section(sectionName).HasKey(property) {
@@ -361,6 +498,16 @@ func GetConfigProperty(sectionName string, property string) (string, error) {
 	return cfg.Section(sectionName).Key(property).String(), nil
 }
 
+// GetConfigPropertyOrDefault returns a property from the config file, or defaultValue if it's not set
+func GetConfigPropertyOrDefault(sectionName string, property string, defaultValue string) string {
+	value, err := GetConfigProperty(sectionName, property)
+	if err != nil || value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
 // GetClientDGO returns a client for DGO
 func GetClientDGO() (*godo.Client, error) {
 	userHome, err := os.UserHomeDir()
@@ -385,207 +532,50 @@ func GetClientDGO() (*godo.Client, error) {
 	return client, nil
 }
 
-// UpdateAWSEnvs updates environment variables in a AWS Amplify app
-func UpdateAWSEnvs(branchInfos *amplify.GetBranchOutput, client *amplify.Client, project string, projEnvironment string, filePath string, envName string, envValue string, appId string) {
-	iniAWS := ini.Empty()
-	isSaved := false
-
-	for envName, envValue := range branchInfos.Branch.EnvironmentVariables {
-		iniAWS.Section("").Key(envName).SetValue(envValue)
-	}
-
-	if filePath != "" {
-		userEnvFile, err := ini.Load(filePath)
-		if err != nil {
-			fmt.Println("Error loading file: ", err)
-			return
-		}
-
-		isSaved, _ = UpdateEnvironmentVariables(iniAWS, userEnvFile)
-
-	} else {
-		if !iniAWS.Section("").HasKey(envName) {
-			fmt.Printf("[WARNING] Environment variable \"%s\" not found in project \"%s\" in \"%s\" environment\n", envName, project, projEnvironment)
-			return
-		}
-
-		iniAWS.Section("").Key(envName).SetValue(envValue)
-		isSaved = true
-	}
-
-	if isSaved {
-		_, err := client.UpdateBranch(context.Background(), &amplify.UpdateBranchInput{
-			AppId:                common.String(appId),
-			BranchName:           branchInfos.Branch.BranchName,
-			EnvironmentVariables: iniAWS.Section("").KeysHash(),
-		})
-
-		if err != nil {
-			fmt.Println("Error updating branch: ", err)
-			return
-		}
-
-		fmt.Printf("Environment variables updated in project \"%s\" in \"%s\" environment\n", project, projEnvironment)
-	}
-}
-
-// DeleteAWSEnvs deletes environment variables in a AWS Amplify app
-func DeleteAWSEnvs(branchInfos *amplify.GetBranchOutput, client *amplify.Client, project string, projEnvironment string, filePath string, envNames []string, isQuiet bool, appId string) {
-	iniAWS := ini.Empty()
-
-	for envName, envValue := range branchInfos.Branch.EnvironmentVariables {
-		iniAWS.Section("").Key(envName).SetValue(envValue)
-	}
-
-	if filePath != "" {
-		userEnvFile, err := ini.Load(filePath)
-		if err != nil {
-			fmt.Println("Error loading file: ", err)
-			if _, err := os.Stat(filePath); err == nil {
-				fmt.Println("Are you sure the file are in INI format (<key>=<value>)?")
-			}
-			return
-		}
-		envNames = userEnvFile.Section("").KeyStrings()
-		fmt.Printf("Deleting from file: %s\n", filePath)
-	}
-
-	isSaved := DeleteEnvironmentVariables(iniAWS, envNames, project, projEnvironment)
-	if isSaved {
-		if isQuiet || GetUserPermission("Are you sure you want to delete the environment variables?") {
-			_, err := client.UpdateBranch(context.Background(), &amplify.UpdateBranchInput{
-				AppId:                common.String(appId),
-				BranchName:           branchInfos.Branch.BranchName,
-				EnvironmentVariables: iniAWS.Section("").KeysHash(),
-			})
-
-			if err != nil {
-				fmt.Println("Error updating branch: ", err)
-				return
-			}
-			fmt.Printf("Environment variables deleted in project \"%s\" in \"%s\" environment\n", project, projEnvironment)
-		}
-	}
-}
-
-// PrintAWSEnvs reads the environment variables from AWS Amplify app
-func PrintAWSEnvs(branchInfos *amplify.GetBranchOutput, project string, projEnvironment string, isGetAll bool, args []string) {
-	if isGetAll {
-		for envName, envValue := range branchInfos.Branch.EnvironmentVariables {
-			fmt.Printf("%s=%s\n", envName, envValue)
-		}
-	} else {
-		envNames := args
-		for _, envName := range envNames {
-			envValue, ok := branchInfos.Branch.EnvironmentVariables[envName]
-			if !ok {
-				fmt.Printf("Environment variable \"%s\" not found in project \"%s\" in \"%s\" environment\n", envName, project, projEnvironment)
-			} else {
-				fmt.Printf("%s=%s\n", envName, envValue)
-			}
-		}
-	}
-}
-
-// CreateAWSEnvs creates environment variables in a AWS Amplify app
-func CreateAWSEnvs(branchInfos *amplify.GetBranchOutput, client *amplify.Client, project string, projEnvironment string, filePath string, envName string, envValue string, appId string) {
-	iniAWS := ini.Empty()
-	isSaved := false
-
-	for envName, envValue := range branchInfos.Branch.EnvironmentVariables {
-		iniAWS.Section("").Key(envName).SetValue(envValue)
-	}
-
-	if filePath != "" {
-		userEnvFile, err := ini.Load(filePath)
-		if err != nil {
-			fmt.Println("Error loading file: ", err)
-			return
-		}
-
-		isSaved, _ = CreateEnvironmentVariables(iniAWS, userEnvFile)
-
-	} else {
-		if iniAWS.Section("").HasKey(envName) {
-			fmt.Printf("[WARNING] Environment variable \"%s\" already exists in project \"%s\" in \"%s\" environment\n", envName, project, projEnvironment)
-			return
-		}
-
-		iniAWS.Section("").Key(envName).SetValue(envValue)
-		isSaved = true
-	}
-
-	if isSaved {
-		_, err := client.UpdateBranch(context.Background(), &amplify.UpdateBranchInput{
-			AppId:                common.String(appId),
-			BranchName:           branchInfos.Branch.BranchName,
-			EnvironmentVariables: iniAWS.Section("").KeysHash(),
-		})
-
-		if err != nil {
-			fmt.Println("Error updating branch: ", err)
-			return
-		}
-
-		fmt.Printf("Environment variables saved in project \"%s\" in \"%s\" environment\n", project, projEnvironment)
-	}
-}
-
 // GetDGOApp gets a DGO App by its project name
-func GetDGOApp(client *godo.Client, project string) *godo.App {
+func GetDGOApp(client *godo.Client, project string) (*godo.App, error) {
 	ctx := context.TODO()
 
 	apps, _, err := client.Apps.List(ctx, nil)
 	if err != nil {
-		log.Fatalf("Error getting apps: %v", err)
+		return nil, fmt.Errorf("error getting apps: %w", err)
 	}
 
-	var specificApp *godo.App
 	for _, app := range apps {
 		if app.Spec.Name == project {
-			specificApp, _, err = client.Apps.Get(ctx, app.ID)
+			specificApp, _, err := client.Apps.Get(ctx, app.ID)
 			if err != nil {
-				log.Fatalf("Error getting app: %v", err)
+				return nil, fmt.Errorf("error getting app: %w", err)
 			}
-			break
+			return specificApp, nil
 		}
 	}
 
-	if specificApp == nil {
-		log.Fatalf("App with project name \"%s\" not found", project)
-	}
-
-	return specificApp
+	return nil, fmt.Errorf("app with project name \"%s\" not found", project)
 }
 
-// GetDGOEnvsAsIni converts a slice of AppVariableDefinition to an ini.File
-func GetDGOEnvsAsIni(appEnvs []*godo.AppVariableDefinition) *ini.File {
+// GetDGOEnvsAsIni converts the AppVariableDefinitions of a type to an ini.File. The "secrets" type
+// matches variables with the SECRET type and the "envs" type matches the others.
+func GetDGOEnvsAsIni(appEnvs []*godo.AppVariableDefinition, envType string) *ini.File {
 	envsAsIni := ini.Empty()
 
 	for _, envVar := range appEnvs {
-		envsAsIni.Section("").NewKey(envVar.Key, envVar.Value)
+		if isDGOEnvType(envVar, envType) {
+			envsAsIni.Section("").NewKey(envVar.Key, envVar.Value)
+		}
 	}
 
 	return envsAsIni
 }
 
-// UpdateDGOApp updates environment variables in a DGO App
-func UpdateDGOApp(client *godo.Client, project string, dgoApp *godo.App, updateFunc func(*godo.AppStaticSiteSpec) (bool, error)) error {
-	isSaved := false
-	var err error
-	err = godo.ForEachAppSpecComponent(dgoApp.Spec, func(component *godo.AppStaticSiteSpec) error {
-		if StringInSlice(component.Name, ValidAppComponents) {
-			isSaved, err = updateFunc(component)
-			if err != nil {
-				return err
-			}
-			if isSaved {
-				return nil
-			}
-		}
-		return nil
-	})
+// UpdateDGOApp updates environment variables of a component in a DGO App
+func UpdateDGOApp(client *godo.Client, dgoApp *godo.App, componentName string, updateFunc func(godo.AppComponentSpec) (bool, error)) error {
+	component, err := GetDGOComponent(dgoApp.Spec, componentName)
+	if err != nil {
+		return err
+	}
 
+	isSaved, err := updateFunc(component)
 	if err != nil {
 		return fmt.Errorf("error updating app components: %w", err)
 	}
@@ -603,6 +593,48 @@ func UpdateDGOApp(client *godo.Client, project string, dgoApp *godo.App, updateF
 	return nil
 }
 
+// GetDGOComponent returns a component of any kind (service, worker, job, static site or functions) by its name
+func GetDGOComponent(spec *godo.AppSpec, componentName string) (godo.AppComponentSpec, error) {
+	component, err := godo.GetAppSpecComponent[godo.AppComponentSpec](spec, componentName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting app component: %w", err)
+	}
+
+	return component, nil
+}
+
+// GetDGOComponentEnvs returns the environment variables of a component
+func GetDGOComponentEnvs(component godo.AppComponentSpec) ([]*godo.AppVariableDefinition, error) {
+	envsComponent, ok := component.(interface {
+		GetEnvs() []*godo.AppVariableDefinition
+	})
+	if !ok {
+		return nil, fmt.Errorf("component \"%s\" of type \"%s\" doesn't have environment variables", component.GetName(), component.GetType())
+	}
+
+	return envsComponent.GetEnvs(), nil
+}
+
+// SetDGOComponentEnvs replaces the environment variables of a component
+func SetDGOComponentEnvs(component godo.AppComponentSpec, appEnvs []*godo.AppVariableDefinition) error {
+	switch c := component.(type) {
+	case *godo.AppServiceSpec:
+		c.Envs = appEnvs
+	case *godo.AppWorkerSpec:
+		c.Envs = appEnvs
+	case *godo.AppJobSpec:
+		c.Envs = appEnvs
+	case *godo.AppStaticSiteSpec:
+		c.Envs = appEnvs
+	case *godo.AppFunctionsSpec:
+		c.Envs = appEnvs
+	default:
+		return fmt.Errorf("component \"%s\" of type \"%s\" doesn't have environment variables", component.GetName(), component.GetType())
+	}
+
+	return nil
+}
+
 // DeleteFromFile deletes environment variables in a ini.File
 func DeleteEnvironmentVariables(envFile *ini.File, envNames []string, project string, projEnvironment string) bool {
 	isSaved := false
@@ -618,18 +650,118 @@ func DeleteEnvironmentVariables(envFile *ini.File, envNames []string, project st
 	return isSaved
 }
 
-// GetDGOEnvsFromIni converts an ini.File to a slice of AppVariableDefinition
-func GetDGOEnvsFromIni(envsAsIni *ini.File) []*godo.AppVariableDefinition {
-	var appEnvs []*godo.AppVariableDefinition
+// GetDGOEnvsFromIni replaces the AppVariableDefinitions of a type with the ones in an ini.File. Variables of
+// the other type and the Type and Scope of existing variables are kept, except for the variables with a scope
+// in variableScopes. Returns an error if a variable already exists with the other type.
+func GetDGOEnvsFromIni(envsAsIni *ini.File, appEnvs []*godo.AppVariableDefinition, envType string, variableScopes map[string]string) ([]*godo.AppVariableDefinition, error) {
+	var newAppEnvs []*godo.AppVariableDefinition
+	existingEnvs := make(map[string]*godo.AppVariableDefinition)
+	otherTypeEnvs := make(map[string]*godo.AppVariableDefinition)
+
+	for _, envVar := range appEnvs {
+		if !isDGOEnvType(envVar, envType) {
+			newAppEnvs = append(newAppEnvs, envVar)
+			otherTypeEnvs[envVar.Key] = envVar
+			continue
+		}
+
+		existingEnvs[envVar.Key] = envVar
+	}
 
 	for _, key := range envsAsIni.Section("").Keys() {
-		appEnvs = append(appEnvs, &godo.AppVariableDefinition{
-			Key:   key.Name(),
-			Value: key.Value(),
-		})
+		if otherTypeEnvs[key.Name()] != nil {
+			return nil, fmt.Errorf("variable \"%s\" already exists with the %s type. Delete it before saving it as %s", key.Name(), getDGOEnvTypeName(otherTypeEnvs[key.Name()]), envType)
+		}
+
+		envVar, ok := existingEnvs[key.Name()]
+		if !ok {
+			envVar = &godo.AppVariableDefinition{Key: key.Name(), Type: godo.AppVariableType_General}
+			if envType == "secrets" {
+				envVar.Type = godo.AppVariableType_Secret
+			}
+		}
+
+		if scope, ok := variableScopes[key.Name()]; ok {
+			envVar.Scope = godo.AppVariableScope(scope)
+		}
+
+		envVar.Value = key.Value()
+		newAppEnvs = append(newAppEnvs, envVar)
+	}
+
+	return newAppEnvs, nil
+}
+
+// getDGOEnvTypeName returns the type of an AppVariableDefinition, which is GENERAL when it's not set
+func getDGOEnvTypeName(envVar *godo.AppVariableDefinition) string {
+	if envVar.Type == "" {
+		return string(godo.AppVariableType_General)
+	}
+	return string(envVar.Type)
+}
+
+// GetUserVariableScopes moves the environment variables in the sections of a user file named after a DGO
+// variable scope, such as [BUILD_TIME], to the default section. Returns the scope of each variable: the one of
+// its section, or defaultScope for the variables in the default section if it's not empty.
+func GetUserVariableScopes(userEnvFile *ini.File, defaultScope string) (map[string]string, error) {
+	variableScopes := make(map[string]string)
+
+	if defaultScope != "" {
+		for _, envName := range userEnvFile.Section("").KeyStrings() {
+			variableScopes[envName] = defaultScope
+		}
 	}
 
-	return appEnvs
+	for _, scope := range ValidScopes {
+		section, err := userEnvFile.GetSection(scope)
+		if err != nil {
+			continue
+		}
+
+		for _, key := range section.Keys() {
+			if userEnvFile.Section("").HasKey(key.Name()) {
+				return nil, fmt.Errorf("environment variable \"%s\" is set more than once", key.Name())
+			}
+
+			userEnvFile.Section("").Key(key.Name()).SetValue(key.Value())
+			variableScopes[key.Name()] = scope
+		}
+
+		userEnvFile.DeleteSection(scope)
+	}
+
+	return variableScopes, nil
+}
+
+// KeepVariableScopes removes the scopes of the variables that are not in envFile, such as the ones skipped by a create
+func KeepVariableScopes(variableScopes map[string]string, envFile *ini.File) {
+	for envName := range variableScopes {
+		if !envFile.Section("").HasKey(envName) {
+			delete(variableScopes, envName)
+		}
+	}
+}
+
+// AddScopeChanges adds the keys given with a scope that UpsertEnvironmentVariables left unchanged to the updated
+// keys, so a DGO scope is saved even when the value is the same, and removes the scopes of the keys that are not
+// saved. Other providers don't store scopes, so only the scopes are removed. Returns the updated keys.
+func AddScopeChanges(provider Provider, target Target, envFile *ini.File, updatedEnvs []string, changedEnvs *ini.File) []string {
+	if provider.Name() == "DGO" {
+		for _, envName := range slices.Sorted(maps.Keys(target.VariableScopes)) {
+			if envFile.Section("").HasKey(envName) && !changedEnvs.Section("").HasKey(envName) {
+				updatedEnvs = append(updatedEnvs, envName)
+				changedEnvs.Section("").Key(envName).SetValue(envFile.Section("").Key(envName).Value())
+			}
+		}
+	}
+
+	KeepVariableScopes(target.VariableScopes, changedEnvs)
+	return updatedEnvs
+}
+
+// isDGOEnvType checks if an AppVariableDefinition is a secret for the "secrets" type, or not for the "envs" type
+func isDGOEnvType(envVar *godo.AppVariableDefinition, envType string) bool {
+	return (envVar.Type == godo.AppVariableType_Secret) == (envType == "secrets")
 }
 
 // CreateEnvironmentVariables creates environment variables in a ini.File
@@ -665,3 +797,26 @@ func UpdateEnvironmentVariables(envFile *ini.File, userEnvsFile *ini.File) (bool
 
 	return isSaved, userEnvsFile
 }
+
+// UpsertEnvironmentVariables creates the missing environment variables from the userEnvsFile and updates the existing
+// ones with a different value. Returns the created and updated keys, and an ini.File with all of them.
+func UpsertEnvironmentVariables(envFile *ini.File, userEnvsFile *ini.File) ([]string, []string, *ini.File) {
+	var createdEnvs []string
+	var updatedEnvs []string
+	changedEnvs := ini.Empty()
+
+	for _, key := range userEnvsFile.Section("").Keys() {
+		if !envFile.Section("").HasKey(key.Name()) {
+			createdEnvs = append(createdEnvs, key.Name())
+		} else if envFile.Section("").Key(key.Name()).Value() != key.Value() {
+			updatedEnvs = append(updatedEnvs, key.Name())
+		} else {
+			continue
+		}
+
+		envFile.Section("").Key(key.Name()).SetValue(key.Value())
+		changedEnvs.Section("").Key(key.Name()).SetValue(key.Value())
+	}
+
+	return createdEnvs, updatedEnvs, changedEnvs
+}
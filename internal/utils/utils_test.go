@@ -0,0 +1,407 @@
+/*
+Copyright © 2025 Stany Helberth stanyhelberth@gmail.com
+*/
+
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"gopkg.in/ini.v1"
+)
+
+// checkEnvs fails the test if the environment variables of an ini.File are not the wanted ones
+func checkEnvs(t *testing.T, name string, got map[string]string, want map[string]string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+		return
+	}
+	for key, value := range want {
+		if gotValue, ok := got[key]; !ok || gotValue != value {
+			t.Errorf("%s = %v, want %v", name, got, want)
+			return
+		}
+	}
+}
+
+func TestCreateEnvironmentVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  map[string]string
+		user      map[string]string
+		wantSaved bool
+		wantEnvs  map[string]string
+		wantUser  map[string]string
+	}{
+		{
+			name:     "new keys",
+			existing: map[string]string{"A": "1"}, user: map[string]string{"B": "2"},
+			wantSaved: true, wantEnvs: map[string]string{"A": "1", "B": "2"}, wantUser: map[string]string{"B": "2"},
+		},
+		{
+			name:     "existing key is skipped",
+			existing: map[string]string{"A": "1"}, user: map[string]string{"A": "x", "B": "2"},
+			wantSaved: true, wantEnvs: map[string]string{"A": "1", "B": "2"}, wantUser: map[string]string{"B": "2"},
+		},
+		{
+			name:     "only existing keys",
+			existing: map[string]string{"A": "1"}, user: map[string]string{"A": "x"},
+			wantSaved: false, wantEnvs: map[string]string{"A": "1"}, wantUser: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envFile := newTestEnvFile(t, tt.existing)
+
+			isSaved, userEnvsFile := CreateEnvironmentVariables(envFile, newTestEnvFile(t, tt.user))
+
+			if isSaved != tt.wantSaved {
+				t.Errorf("isSaved = %v, want %v", isSaved, tt.wantSaved)
+			}
+			checkEnvs(t, "envs", envFile.Section("").KeysHash(), tt.wantEnvs)
+			checkEnvs(t, "user envs", userEnvsFile.Section("").KeysHash(), tt.wantUser)
+		})
+	}
+}
+
+func TestUpdateEnvironmentVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  map[string]string
+		user      map[string]string
+		wantSaved bool
+		wantEnvs  map[string]string
+		wantUser  map[string]string
+	}{
+		{
+			name:     "existing key",
+			existing: map[string]string{"A": "1", "B": "2"}, user: map[string]string{"A": "x"},
+			wantSaved: true, wantEnvs: map[string]string{"A": "x", "B": "2"}, wantUser: map[string]string{"A": "x"},
+		},
+		{
+			name:     "missing key is skipped",
+			existing: map[string]string{"A": "1"}, user: map[string]string{"A": "x", "B": "2"},
+			wantSaved: true, wantEnvs: map[string]string{"A": "x"}, wantUser: map[string]string{"A": "x"},
+		},
+		{
+			name:     "only missing keys",
+			existing: map[string]string{"A": "1"}, user: map[string]string{"B": "2"},
+			wantSaved: false, wantEnvs: map[string]string{"A": "1"}, wantUser: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envFile := newTestEnvFile(t, tt.existing)
+
+			isSaved, userEnvsFile := UpdateEnvironmentVariables(envFile, newTestEnvFile(t, tt.user))
+
+			if isSaved != tt.wantSaved {
+				t.Errorf("isSaved = %v, want %v", isSaved, tt.wantSaved)
+			}
+			checkEnvs(t, "envs", envFile.Section("").KeysHash(), tt.wantEnvs)
+			checkEnvs(t, "user envs", userEnvsFile.Section("").KeysHash(), tt.wantUser)
+		})
+	}
+}
+
+func TestDeleteEnvironmentVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  map[string]string
+		envNames  []string
+		wantSaved bool
+		wantEnvs  map[string]string
+	}{
+		{
+			name:     "existing keys",
+			existing: map[string]string{"A": "1", "B": "2", "C": "3"}, envNames: []string{"A", "C"},
+			wantSaved: true, wantEnvs: map[string]string{"B": "2"},
+		},
+		{
+			name:     "missing key is skipped",
+			existing: map[string]string{"A": "1"}, envNames: []string{"A", "B"},
+			wantSaved: true, wantEnvs: map[string]string{},
+		},
+		{
+			name:     "only missing keys",
+			existing: map[string]string{"A": "1"}, envNames: []string{"B"},
+			wantSaved: false, wantEnvs: map[string]string{"A": "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envFile := newTestEnvFile(t, tt.existing)
+
+			isSaved := DeleteEnvironmentVariables(envFile, tt.envNames, "proj", "dev")
+
+			if isSaved != tt.wantSaved {
+				t.Errorf("isSaved = %v, want %v", isSaved, tt.wantSaved)
+			}
+			checkEnvs(t, "envs", envFile.Section("").KeysHash(), tt.wantEnvs)
+		})
+	}
+}
+
+func TestUpsertEnvironmentVariables(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    map[string]string
+		user        map[string]string
+		wantCreated []string
+		wantUpdated []string
+		wantEnvs    map[string]string
+		wantChanged map[string]string
+	}{
+		{
+			name:     "create and update",
+			existing: map[string]string{"A": "1", "B": "2"}, user: map[string]string{"A": "x", "B": "2", "C": "3"},
+			wantCreated: []string{"C"}, wantUpdated: []string{"A"},
+			wantEnvs:    map[string]string{"A": "x", "B": "2", "C": "3"},
+			wantChanged: map[string]string{"A": "x", "C": "3"},
+		},
+		{
+			name:     "unchanged",
+			existing: map[string]string{"A": "1"}, user: map[string]string{"A": "1"},
+			wantEnvs: map[string]string{"A": "1"}, wantChanged: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envFile := newTestEnvFile(t, tt.existing)
+
+			createdEnvs, updatedEnvs, changedEnvs := UpsertEnvironmentVariables(envFile, newTestEnvFile(t, tt.user))
+
+			slices.Sort(createdEnvs)
+			slices.Sort(updatedEnvs)
+			if !slices.Equal(createdEnvs, tt.wantCreated) {
+				t.Errorf("created = %v, want %v", createdEnvs, tt.wantCreated)
+			}
+			if !slices.Equal(updatedEnvs, tt.wantUpdated) {
+				t.Errorf("updated = %v, want %v", updatedEnvs, tt.wantUpdated)
+			}
+			checkEnvs(t, "envs", envFile.Section("").KeysHash(), tt.wantEnvs)
+			checkEnvs(t, "changed envs", changedEnvs.Section("").KeysHash(), tt.wantChanged)
+		})
+	}
+}
+
+func TestAddScopeChanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerName string
+		scopes       map[string]string
+		wantUpdated  []string
+		wantChanged  map[string]string
+		wantScopes   map[string]string
+	}{
+		{
+			name: "scope of an unchanged key", providerName: "DGO",
+			scopes:      map[string]string{"B": "BUILD_TIME"},
+			wantUpdated: []string{"A", "B"}, wantChanged: map[string]string{"A": "x", "B": "2"},
+			wantScopes: map[string]string{"B": "BUILD_TIME"},
+		},
+		{
+			name: "scope of a changed key", providerName: "DGO",
+			scopes:      map[string]string{"A": "RUN_TIME"},
+			wantUpdated: []string{"A"}, wantChanged: map[string]string{"A": "x"},
+			wantScopes: map[string]string{"A": "RUN_TIME"},
+		},
+		{
+			name: "no scopes", providerName: "DGO",
+			scopes:      map[string]string{},
+			wantUpdated: []string{"A"}, wantChanged: map[string]string{"A": "x"},
+			wantScopes: map[string]string{},
+		},
+		{
+			name: "other providers don't store scopes", providerName: "FILE",
+			scopes:      map[string]string{"B": "BUILD_TIME"},
+			wantUpdated: []string{"A"}, wantChanged: map[string]string{"A": "x"},
+			wantScopes: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &namedProvider{name: tt.providerName}
+			target := Target{Project: "p1", Environment: "dev", EnvType: "envs", VariableScopes: tt.scopes}
+			envFile := newTestEnvFile(t, map[string]string{"A": "1", "B": "2"})
+
+			_, updatedEnvs, changedEnvs := UpsertEnvironmentVariables(envFile, newTestEnvFile(t, map[string]string{"A": "x", "B": "2"}))
+			updatedEnvs = AddScopeChanges(provider, target, envFile, updatedEnvs, changedEnvs)
+
+			slices.Sort(updatedEnvs)
+			if !slices.Equal(updatedEnvs, tt.wantUpdated) {
+				t.Errorf("updated = %v, want %v", updatedEnvs, tt.wantUpdated)
+			}
+			checkEnvs(t, "changed envs", changedEnvs.Section("").KeysHash(), tt.wantChanged)
+			checkEnvs(t, "scopes", target.VariableScopes, tt.wantScopes)
+		})
+	}
+}
+
+func TestGetDGOEnvsFromIni(t *testing.T) {
+	existingEnvs := func() []*godo.AppVariableDefinition {
+		return []*godo.AppVariableDefinition{
+			{Key: "GENERAL", Value: "1", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_BuildTime},
+			{Key: "UNSET_TYPE", Value: "2", Scope: godo.AppVariableScope_RunTime},
+			{Key: "SECRET", Value: "EV[1:abc]", Type: godo.AppVariableType_Secret, Scope: godo.AppVariableScope_RunTime},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		envType        string
+		variableScopes map[string]string
+		envs           [][2]string
+		want           []godo.AppVariableDefinition
+		wantErr        bool
+	}{
+		{
+			name:    "envs keep type and scope",
+			envType: "envs",
+			envs:    [][2]string{{"GENERAL", "1"}, {"UNSET_TYPE", "changed"}},
+			want: []godo.AppVariableDefinition{
+				{Key: "SECRET", Value: "EV[1:abc]", Type: godo.AppVariableType_Secret, Scope: godo.AppVariableScope_RunTime},
+				{Key: "GENERAL", Value: "1", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_BuildTime},
+				{Key: "UNSET_TYPE", Value: "changed", Scope: godo.AppVariableScope_RunTime},
+			},
+		},
+		{
+			name:    "new envs are general",
+			envType: "envs",
+			envs:    [][2]string{{"NEW", "x"}},
+			want: []godo.AppVariableDefinition{
+				{Key: "SECRET", Value: "EV[1:abc]", Type: godo.AppVariableType_Secret, Scope: godo.AppVariableScope_RunTime},
+				{Key: "NEW", Value: "x", Type: godo.AppVariableType_General},
+			},
+		},
+		{
+			name:           "scopes are set by key, even without a value change",
+			envType:        "envs",
+			variableScopes: map[string]string{"GENERAL": "RUN_TIME", "NEW": "RUN_AND_BUILD_TIME"},
+			envs:           [][2]string{{"GENERAL", "1"}, {"UNSET_TYPE", "changed"}, {"NEW", "x"}},
+			want: []godo.AppVariableDefinition{
+				{Key: "SECRET", Value: "EV[1:abc]", Type: godo.AppVariableType_Secret, Scope: godo.AppVariableScope_RunTime},
+				{Key: "GENERAL", Value: "1", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_RunTime},
+				{Key: "UNSET_TYPE", Value: "changed", Scope: godo.AppVariableScope_RunTime},
+				{Key: "NEW", Value: "x", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_RunAndBuildTime},
+			},
+		},
+		{
+			name:    "secrets keep envs",
+			envType: "secrets",
+			envs:    [][2]string{{"TOKEN", "t"}},
+			want: []godo.AppVariableDefinition{
+				{Key: "GENERAL", Value: "1", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_BuildTime},
+				{Key: "UNSET_TYPE", Value: "2", Scope: godo.AppVariableScope_RunTime},
+				{Key: "TOKEN", Value: "t", Type: godo.AppVariableType_Secret},
+			},
+		},
+		{
+			name:    "env with the key of a secret",
+			envType: "envs",
+			envs:    [][2]string{{"SECRET", "plain"}},
+			wantErr: true,
+		},
+		{
+			name:    "secret with the key of an env without type",
+			envType: "secrets",
+			envs:    [][2]string{{"UNSET_TYPE", "s"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envsAsIni := newTestEnvFile(t, nil)
+			for _, env := range tt.envs {
+				envsAsIni.Section("").Key(env[0]).SetValue(env[1])
+			}
+
+			got, err := GetDGOEnvsFromIni(envsAsIni, existingEnvs(), tt.envType, tt.variableScopes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d variables, want %d", len(got), len(tt.want))
+			}
+			for i, envVar := range got {
+				if *envVar != tt.want[i] {
+					t.Errorf("variable %d = %+v, want %+v", i, *envVar, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetUserVariableScopes(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		defaultScope string
+		wantEnvs     map[string]string
+		wantScopes   map[string]string
+		wantErr      bool
+	}{
+		{
+			name:       "no scopes",
+			content:    "A=1\nB=2\n",
+			wantEnvs:   map[string]string{"A": "1", "B": "2"},
+			wantScopes: map[string]string{},
+		},
+		{
+			name:         "default scope",
+			content:      "A=1\n",
+			defaultScope: "RUN_TIME",
+			wantEnvs:     map[string]string{"A": "1"},
+			wantScopes:   map[string]string{"A": "RUN_TIME"},
+		},
+		{
+			name:         "scope sections",
+			content:      "A=1\n[BUILD_TIME]\nB=2\n[RUN_AND_BUILD_TIME]\nC=3\n",
+			defaultScope: "RUN_TIME",
+			wantEnvs:     map[string]string{"A": "1", "B": "2", "C": "3"},
+			wantScopes:   map[string]string{"A": "RUN_TIME", "B": "BUILD_TIME", "C": "RUN_AND_BUILD_TIME"},
+		},
+		{
+			name:       "other sections are ignored",
+			content:    "A=1\n[other]\nB=2\n",
+			wantEnvs:   map[string]string{"A": "1"},
+			wantScopes: map[string]string{},
+		},
+		{
+			name:    "key in two sections",
+			content: "A=1\n[BUILD_TIME]\nA=2\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userEnvFile, err := ini.Load([]byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			variableScopes, err := GetUserVariableScopes(userEnvFile, tt.defaultScope)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			checkEnvs(t, "envs", userEnvFile.Section("").KeysHash(), tt.wantEnvs)
+			checkEnvs(t, "scopes", variableScopes, tt.wantScopes)
+		})
+	}
+}